@@ -1,9 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"os"
 	"time"
 
+	"github.com/RagOfJoes/puzzlely/internal/cmd/migrate"
 	"github.com/RagOfJoes/puzzlely/internal/cmd/web"
 )
 
@@ -13,7 +16,26 @@ func init() {
 }
 
 func main() {
-	if err := web.Run(); err != nil {
+	if err := run(os.Args[1:]); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// Dispatches to the given subcommand. Defaults to `start` to stay compatible with running the binary without any
+// arguments
+func run(args []string) error {
+	command := "start"
+	if len(args) > 0 {
+		command = args[0]
+		args = args[1:]
+	}
+
+	switch command {
+	case "start":
+		return web.Run()
+	case "migrate":
+		return migrate.Run(args)
+	default:
+		return fmt.Errorf("Unknown command %q. Must be one of: start, migrate.", command)
+	}
+}
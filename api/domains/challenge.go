@@ -0,0 +1,110 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/oklog/ulid/v2"
+	"github.com/uptrace/bun"
+)
+
+var _ Domain = (*Challenge)(nil)
+
+// Challenge defines a head-to-head match on a puzzle. It's created by a user that has completed the puzzle and is
+// shared as a link. The first other user to accept it becomes the opponent and plays the same puzzle
+type Challenge struct {
+	bun.BaseModel
+
+	ID string `bun:"type:varchar(26),pk,notnull" json:"id"`
+
+	CreatedAt  time.Time    `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+	AcceptedAt bun.NullTime `bun:",nullzero,default:NULL" json:"accepted_at"`
+
+	// GameID defines the completed game that the challenge was created from
+	GameID   string `bun:"type:varchar(26),notnull" json:"-"`
+	PuzzleID string `bun:"type:varchar(26),notnull" json:"puzzle_id"`
+	// UserID defines the user that created the challenge
+	UserID string `bun:"type:varchar(26),notnull" json:"-"`
+	User   User   `bun:"rel:belongs-to,join:user_id=id" json:"user"`
+	// OpponentID defines the user that accepted the challenge. Empty until it has been accepted
+	OpponentID string `bun:"type:varchar(26),nullzero" json:"-"`
+	Opponent   *User  `bun:"rel:belongs-to,join:opponent_id=id" json:"opponent"`
+
+	// Result defines the challenger's placement on the puzzle's leaderboard
+	Result *LeaderboardEntry `bun:"-" json:"result"`
+	// OpponentResult defines the opponent's placement on the puzzle's leaderboard. Empty until they've completed the
+	// puzzle
+	OpponentResult *LeaderboardEntry `bun:"-" json:"opponent_result"`
+	// WinnerID defines the user with the better result. Empty while the opponent is still playing or if it's a tie
+	WinnerID string `bun:"-" json:"winner_id"`
+}
+
+// NewChallenge creates a challenge from the given completed game
+func NewChallenge(game Game) Challenge {
+	return Challenge{
+		ID: ulid.Make().String(),
+
+		CreatedAt: time.Now(),
+
+		GameID:   game.ID,
+		PuzzleID: game.PuzzleID,
+		UserID:   game.UserID,
+		User:     game.User,
+	}
+}
+
+// IsAccepted checks whether an opponent has accepted the challenge
+func (c Challenge) IsAccepted() bool {
+	return !c.AcceptedAt.IsZero()
+}
+
+// IsParticipant checks whether the given user is either the challenger or the opponent
+func (c Challenge) IsParticipant(id string) bool {
+	return id != "" && (c.UserID == id || c.OpponentID == id)
+}
+
+// Compare sets the results of both participants and decides the winner. Like the leaderboard, a higher score wins,
+// followed by fewer attempts, followed by a timed game, followed by a faster solve time
+func (c *Challenge) Compare(result *LeaderboardEntry, opponentResult *LeaderboardEntry) {
+	c.Result = result
+	c.OpponentResult = opponentResult
+	c.WinnerID = ""
+
+	if result == nil || opponentResult == nil {
+		return
+	}
+
+	switch {
+	case result.Score != opponentResult.Score:
+		c.WinnerID = c.winner(result.Score > opponentResult.Score)
+	case result.Attempts != opponentResult.Attempts:
+		c.WinnerID = c.winner(result.Attempts < opponentResult.Attempts)
+	case result.Timed != opponentResult.Timed:
+		c.WinnerID = c.winner(result.Timed)
+	case result.SolveTime != opponentResult.SolveTime:
+		c.WinnerID = c.winner(result.SolveTime < opponentResult.SolveTime)
+	}
+}
+
+func (c Challenge) winner(isChallenger bool) string {
+	if isChallenger {
+		return c.UserID
+	}
+
+	return c.OpponentID
+}
+
+func (c Challenge) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.ID, validation.Required, validation.By(internal.IsULID)),
+
+		validation.Field(&c.CreatedAt, validation.Required),
+		validation.Field(&c.AcceptedAt, validation.When(c.IsAccepted(), validation.By(internal.IsAfter(c.CreatedAt)))),
+
+		validation.Field(&c.GameID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&c.PuzzleID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&c.UserID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&c.OpponentID, validation.When(c.IsAccepted(), validation.Required), validation.When(c.OpponentID != "", validation.By(internal.IsULID), validation.NotIn(c.UserID))),
+	)
+}
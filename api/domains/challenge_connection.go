@@ -0,0 +1,51 @@
+package domains
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*ChallengeConnection)(nil)
+
+type ChallengeConnection struct {
+	Edges    []ChallengeEdge `json:"edges"`
+	PageInfo PageInfo        `json:"page_info"`
+}
+
+// BuildChallengeConnection builds a connection for challenges ordered from newest to oldest
+func BuildChallengeConnection(nodes []Challenge, limit int) (*ChallengeConnection, error) {
+	edges := make([]ChallengeEdge, 0)
+	for _, node := range nodes {
+		edges = append(edges, ChallengeEdge{
+			Cursor: NewCursor(node.CreatedAt.Format("2006-01-02 15:04:05.000000")),
+			Node:   node,
+		})
+	}
+
+	pageInfo := PageInfo{
+		HasNextPage:     len(edges) > limit,
+		HasPreviousPage: false,
+		NextCursor:      "",
+		PreviousCursor:  "",
+	}
+	if pageInfo.HasNextPage {
+		pageInfo.NextCursor = edges[len(edges)-1].Cursor
+		edges = edges[:len(edges)-1]
+	}
+
+	connection := ChallengeConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}
+	if err := connection.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
+func (c ChallengeConnection) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Edges, validation.NotNil),
+		validation.Field(&c.PageInfo, validation.Required),
+	)
+}
@@ -0,0 +1,17 @@
+package domains
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+var _ Domain = (*ChallengeCursorPaginationOpts)(nil)
+
+type ChallengeCursorPaginationOpts struct {
+	Cursor Cursor `json:"-"`
+	Limit  int    `json:"-"`
+}
+
+func (c ChallengeCursorPaginationOpts) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Cursor),
+		validation.Field(&c.Limit, validation.Min(1), validation.Max(99)),
+	)
+}
@@ -0,0 +1,18 @@
+package domains
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+var _ Domain = (*ChallengeEdge)(nil)
+
+// ChallengeEdge defines a paginated challenge list item
+type ChallengeEdge struct {
+	Cursor Cursor    `json:"cursor"`
+	Node   Challenge `json:"node"`
+}
+
+func (c ChallengeEdge) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Cursor, validation.Required),
+		validation.Field(&c.Node, validation.Required),
+	)
+}
@@ -0,0 +1,27 @@
+package domains
+
+import (
+	"net/http"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/go-chi/render"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*ChallengePayload)(nil)
+var _ render.Binder = (*ChallengePayload)(nil)
+
+// ChallengePayload defines the payload for challenging others on a puzzle that the user has completed
+type ChallengePayload struct {
+	PuzzleID string `json:"puzzle_id"`
+}
+
+func (c ChallengePayload) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.PuzzleID, validation.Required, validation.By(internal.IsULID)),
+	)
+}
+
+func (c *ChallengePayload) Bind(r *http.Request) error {
+	return nil
+}
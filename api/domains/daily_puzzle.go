@@ -0,0 +1,42 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// DailyPuzzleDateLayout is the layout used for daily puzzle dates in requests, cursors, and queries
+	DailyPuzzleDateLayout = "2006-01-02"
+)
+
+var _ Domain = (*DailyPuzzle)(nil)
+
+// DailyPuzzle defines the puzzle that is scheduled to be the puzzle of the day
+type DailyPuzzle struct {
+	bun.BaseModel
+
+	// Date defines the day, in the configured timezone, that the puzzle is the puzzle of the day
+	Date time.Time `bun:"type:date,pk,notnull" json:"date"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+
+	PuzzleID string `bun:"type:varchar(26),notnull" json:"-"`
+	Puzzle   Puzzle `bun:"-" json:"puzzle"`
+	// UserID defines the admin that scheduled the puzzle
+	UserID string `bun:"type:varchar(26),notnull" json:"-"`
+}
+
+func (d DailyPuzzle) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Date, validation.Required),
+
+		validation.Field(&d.CreatedAt, validation.Required),
+
+		validation.Field(&d.PuzzleID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&d.UserID, validation.Required, validation.By(internal.IsULID)),
+	)
+}
@@ -0,0 +1,30 @@
+package domains
+
+import (
+	"net/http"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/go-chi/render"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*DailyPuzzlePayload)(nil)
+var _ render.Binder = (*DailyPuzzlePayload)(nil)
+
+// DailyPuzzlePayload defines the payload for scheduling a daily puzzle
+type DailyPuzzlePayload struct {
+	PuzzleID string `json:"puzzle_id"`
+	// Date is optional. When empty the puzzle is scheduled for the day after the last scheduled puzzle
+	Date string `json:"date"`
+}
+
+func (d DailyPuzzlePayload) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.PuzzleID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&d.Date, validation.Date(DailyPuzzleDateLayout)),
+	)
+}
+
+func (d *DailyPuzzlePayload) Bind(r *http.Request) error {
+	return nil
+}
@@ -1,6 +1,7 @@
 package domains
 
 import (
+	"errors"
 	"slices"
 	"time"
 
@@ -10,6 +11,14 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// Errors
+var (
+	ErrGameCompleted        = errors.New("Game has already been completed.")
+	ErrGameInvalidGuess     = errors.New("Guess must only contain blocks from unsolved groups in the puzzle.")
+	ErrGameInvalidGuessSize = errors.New("Guess must have as many blocks as a group.")
+	ErrGameNoHints          = errors.New("There are no hints of that type left to reveal.")
+)
+
 var _ Domain = (*Game)(nil)
 
 type Game struct {
@@ -19,14 +28,22 @@ type Game struct {
 	Score    int8       `bun:",notnull" json:"score"`
 	Attempts [][]string `bun:"-" json:"attempts"`
 	Correct  []string   `bun:"-" json:"correct"`
+	// Hints defines the hints that were used, in the order that they were used
+	Hints []GameHint `bun:"-" json:"hints"`
+
+	// Revision defines how many times the game has been saved. Games are only saved on top of the revision that they
+	// were loaded from so that concurrent requests can't overwrite each other
+	Revision int `bun:",notnull,default:0" json:"-"`
 
 	CreatedAt   time.Time    `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
 	CompletedAt bun.NullTime `bun:",nullzero,default:NULL" json:"completed_at"`
 
 	PuzzleID string `bun:"type:varchar(26),notnull" json:"-"`
-	Puzzle   Puzzle `bun:"rel:has-one,join:puzzle_id=id" json:"puzzle"`
-	UserID   string `bun:"type:varchar(26),notnull" json:"-"`
-	User     User   `bun:"rel:belongs-to,join:user_id=id" json:"user"`
+	// PuzzleVersion defines the version of the puzzle that the game was started on
+	PuzzleVersion int    `bun:",notnull,default:1" json:"puzzle_version"`
+	Puzzle        Puzzle `bun:"rel:has-one,join:puzzle_id=id" json:"puzzle"`
+	UserID        string `bun:"type:varchar(26),notnull" json:"-"`
+	User          User   `bun:"rel:belongs-to,join:user_id=id" json:"user"`
 }
 
 func NewGame() Game {
@@ -35,21 +52,152 @@ func NewGame() Game {
 		Score:    0,
 		Attempts: make([][]string, 0),
 		Correct:  make([]string, 0),
+		Hints:    make([]GameHint, 0),
 
 		CreatedAt: time.Now(),
 	}
 }
 
 func (g *Game) Complete(atttemps [][]string, correct []string) {
-	g.Score = int8(len(correct))
 	g.Attempts = atttemps
 	g.Correct = correct
+	g.Score = g.score()
 
 	g.CompletedAt = bun.NullTime{
 		Time: time.Now(),
 	}
 }
 
+// GiveUp ends the game without changing its attempts or correct groups
+func (g *Game) GiveUp() {
+	if !g.CompletedAt.IsZero() {
+		return
+	}
+
+	g.Complete(g.Attempts, g.Correct)
+}
+
+// Guess checks the given blocks against the puzzle's groups and appends the result to the game. The game is completed
+// once every group has been found or once the number of wrong attempts reaches the puzzle's `MaxAttempts`
+//
+// NOTE: `Puzzle` must be loaded with its groups and blocks
+func (g *Game) Guess(blocks []string) error {
+	if !g.CompletedAt.IsZero() {
+		return ErrGameCompleted
+	}
+
+	// Map each block to the group it belongs to
+	groups := make(map[string]string, 0)
+	for _, group := range g.Puzzle.Groups {
+		for _, block := range group.Blocks {
+			groups[block.ID] = group.ID
+		}
+	}
+
+	if len(blocks) != g.Puzzle.GroupSize {
+		return ErrGameInvalidGuessSize
+	}
+
+	seen := make(map[string]bool, 0)
+	for _, block := range blocks {
+		group, ok := groups[block]
+		if !ok || seen[block] || slices.Contains(g.Correct, group) {
+			return ErrGameInvalidGuess
+		}
+
+		seen[block] = true
+	}
+
+	g.Attempts = append(g.Attempts, slices.Clone(blocks))
+
+	isCorrect := len(blocks) > 0
+	for _, block := range blocks {
+		if groups[block] != groups[blocks[0]] {
+			isCorrect = false
+			break
+		}
+	}
+
+	if !isCorrect {
+		if g.WrongAttempts() >= int(g.Puzzle.MaxAttempts) {
+			g.Complete(g.Attempts, g.Correct)
+		}
+
+		return nil
+	}
+
+	g.Correct = append(g.Correct, groups[blocks[0]])
+	g.Score = g.score()
+
+	// If there's only one group left then it is solved automatically
+	if len(g.Correct) == len(g.Puzzle.Groups)-1 {
+		for _, group := range g.Puzzle.Groups {
+			if slices.Contains(g.Correct, group.ID) {
+				continue
+			}
+
+			last := make([]string, 0)
+			for _, block := range group.Blocks {
+				last = append(last, block.ID)
+			}
+
+			g.Attempts = append(g.Attempts, last)
+			g.Correct = append(g.Correct, group.ID)
+		}
+	}
+
+	if len(g.Correct) == len(g.Puzzle.Groups) {
+		g.Complete(g.Attempts, g.Correct)
+	}
+
+	return nil
+}
+
+// Hint reveals a hint of the given type for the first unsolved group that still has one to reveal. Groups can reveal
+// their creator's hint, if they have one, and a single block
+//
+// NOTE: `Puzzle` must be loaded with its groups and blocks
+func (g *Game) Hint(hintType string) (*GameHint, error) {
+	if !g.CompletedAt.IsZero() {
+		return nil, ErrGameCompleted
+	}
+
+	for _, group := range g.Puzzle.Groups {
+		if slices.Contains(g.Correct, group.ID) || len(group.Blocks) == 0 {
+			continue
+		}
+		if hintType == GameHintTypeHint && group.Hint == "" {
+			continue
+		}
+
+		isUsed := slices.ContainsFunc(g.Hints, func(hint GameHint) bool {
+			return hint.Type == hintType && hint.PuzzleGroupID == group.ID
+		})
+		if isUsed {
+			continue
+		}
+
+		hint := GameHint{
+			ID:    ulid.Make().String(),
+			Order: len(g.Hints),
+			Type:  hintType,
+
+			PuzzleGroupID: group.ID,
+			GameID:        g.ID,
+		}
+		if hintType == GameHintTypeBlock {
+			hint.PuzzleBlockID = group.Blocks[0].ID
+		}
+
+		g.Hints = append(g.Hints, hint)
+		g.Score = g.score()
+
+		return &hint, nil
+	}
+
+	return nil, ErrGameNoHints
+}
+
 // IsAhead checks whether the current `Game` is ahead of the given `Game`
 func (g Game) IsAhead(of Game) bool {
 	if !g.CompletedAt.IsZero() && of.CompletedAt.IsZero() {
@@ -103,6 +251,16 @@ func (g Game) IsContinuation(other Game) bool {
 	return true
 }
 
+// Returns the number of correct groups less a point for every hint that was used. The score never goes below zero
+func (g Game) score() int8 {
+	return int8(max(len(g.Correct)-len(g.Hints), 0))
+}
+
+// WrongAttempts returns the number of attempts that did not match a group
+func (g Game) WrongAttempts() int {
+	return len(g.Attempts) - len(g.Correct)
+}
+
 func (g Game) Validate() error {
 	blocks := make([]interface{}, 0)
 	groups := make([]interface{}, 0)
@@ -116,9 +274,10 @@ func (g Game) Validate() error {
 
 	return validation.ValidateStruct(&g,
 		validation.Field(&g.ID, validation.Required, validation.By(internal.IsULID)),
-		validation.Field(&g.Score, validation.Min(int8(len(g.Correct))), validation.Max(int8(len(g.Correct)))),
-		validation.Field(&g.Attempts, validation.Each(validation.Required, validation.Length(4, 4), validation.Each(validation.In(blocks...)))),
-		validation.Field(&g.Correct, validation.Length(0, 4), validation.Each(validation.In(groups...))),
+		validation.Field(&g.Score, validation.Min(g.score()), validation.Max(g.score())),
+		validation.Field(&g.Attempts, validation.Each(validation.Required, validation.Length(g.Puzzle.GroupSize, g.Puzzle.GroupSize), validation.Each(validation.In(blocks...)))),
+		validation.Field(&g.Correct, validation.Length(0, g.Puzzle.GroupCount), validation.Each(validation.In(groups...))),
+		validation.Field(&g.Hints, validation.Each(validation.Required)),
 
 		validation.Field(&g.CreatedAt, validation.Required),
 
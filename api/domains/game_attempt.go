@@ -20,7 +20,7 @@ func (g GameAttempt) Validate() error {
 	return validation.ValidateStruct(&g,
 		validation.Field(&g.ID, validation.Required, validation.By(internal.IsULID)),
 		validation.Field(&g.AttemptOrder, validation.Min(0)),
-		validation.Field(&g.SelectionOrder, validation.Required, validation.Min(0), validation.Max(3)),
+		validation.Field(&g.SelectionOrder, validation.Required, validation.Min(0), validation.Max(PuzzleMaxGroupSize-1)),
 
 		validation.Field(&g.PuzzleBlockID, validation.Required, validation.By(internal.IsULID)),
 		validation.Field(&g.GameID, validation.Required, validation.By(internal.IsULID)),
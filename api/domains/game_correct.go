@@ -18,7 +18,7 @@ type GameCorrect struct {
 func (g GameCorrect) Validate() error {
 	return validation.ValidateStruct(&g,
 		validation.Field(&g.ID, validation.Required, validation.By(internal.IsULID)),
-		validation.Field(&g.Order, validation.Max(3), validation.Min(0)),
+		validation.Field(&g.Order, validation.Max(PuzzleMaxGroupCount-1), validation.Min(0)),
 
 		validation.Field(&g.PuzzleGroupID, validation.Required, validation.By(internal.IsULID)),
 		validation.Field(&g.GameID, validation.Required, validation.By(internal.IsULID)),
@@ -0,0 +1,26 @@
+package domains
+
+import (
+	"net/http"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/go-chi/render"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*GameGuessPayload)(nil)
+var _ render.Binder = (*GameGuessPayload)(nil)
+
+type GameGuessPayload struct {
+	Blocks []string `json:"blocks"`
+}
+
+func (g *GameGuessPayload) Bind(r *http.Request) error {
+	return nil
+}
+
+func (g GameGuessPayload) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.Blocks, validation.Required, validation.Length(PuzzleMinGroupSize, PuzzleMaxGroupSize), validation.Each(validation.Required, validation.By(internal.IsULID))),
+	)
+}
@@ -0,0 +1,43 @@
+package domains
+
+import (
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Types of hints that can be used in a game
+const (
+	// GameHintTypeHint reveals the hint that the creator wrote for a group
+	GameHintTypeHint = "HINT"
+	// GameHintTypeBlock reveals a single block of a group
+	GameHintTypeBlock = "BLOCK"
+)
+
+// GameHintTypes defines the types of hints that can be used in a game
+var GameHintTypes = []interface{}{GameHintTypeHint, GameHintTypeBlock}
+
+var _ Domain = (*GameHint)(nil)
+
+// GameHint defines a hint that was used in a game. Every hint costs the game a point of its score
+type GameHint struct {
+	ID    string `bun:"type:varchar(26),pk,notnull" json:"-"`
+	Order int    `bun:",notnull" json:"-"`
+	Type  string `bun:"type:varchar(8),notnull" json:"type"`
+
+	PuzzleGroupID string `bun:"type:varchar(26),notnull" json:"puzzle_group_id"`
+	// PuzzleBlockID defines the block that was revealed. This is only set for `GameHintTypeBlock`
+	PuzzleBlockID string `bun:"type:varchar(26),nullzero" json:"puzzle_block_id,omitempty"`
+	GameID        string `bun:"type:varchar(26),notnull" json:"-"`
+}
+
+func (g GameHint) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&g.Order, validation.Min(0)),
+		validation.Field(&g.Type, validation.Required, validation.In(GameHintTypes...)),
+
+		validation.Field(&g.PuzzleGroupID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&g.PuzzleBlockID, validation.When(g.Type == GameHintTypeBlock, validation.Required, validation.By(internal.IsULID)).Else(validation.Empty)),
+		validation.Field(&g.GameID, validation.When(g.GameID != "", validation.By(internal.IsULID))),
+	)
+}
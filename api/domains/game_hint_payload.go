@@ -0,0 +1,26 @@
+package domains
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*GameHintPayload)(nil)
+var _ render.Binder = (*GameHintPayload)(nil)
+
+// GameHintPayload defines the payload for using a hint in a game
+type GameHintPayload struct {
+	Type string `json:"type"`
+}
+
+func (g *GameHintPayload) Bind(r *http.Request) error {
+	return nil
+}
+
+func (g GameHintPayload) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.Type, validation.Required, validation.In(GameHintTypes...)),
+	)
+}
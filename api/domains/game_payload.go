@@ -27,9 +27,10 @@ func (g *GamePayload) Bind(r *http.Request) error {
 
 func (g GamePayload) Validate() error {
 	return validation.ValidateStruct(&g,
-		validation.Field(&g.Score, validation.Min(int8(len(g.Correct))), validation.Max(int8(len(g.Correct)))),
-		validation.Field(&g.Attempts, validation.Each(validation.Required, validation.Length(4, 4))),
-		validation.Field(&g.Correct, validation.Length(0, 4)),
+		// Hints take points off of the score so it can be lower than the number of correct groups
+		validation.Field(&g.Score, validation.Min(int8(0)), validation.Max(int8(len(g.Correct)))),
+		validation.Field(&g.Attempts, validation.Each(validation.Required, validation.Length(PuzzleMinGroupSize, PuzzleMaxGroupSize))),
+		validation.Field(&g.Correct, validation.Length(0, PuzzleMaxGroupCount)),
 
 		validation.Field(&g.CompletedAt, validation.When(!g.CompletedAt.IsZero(), validation.By(internal.IsBefore(time.Now())))),
 	)
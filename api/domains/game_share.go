@@ -0,0 +1,81 @@
+package domains
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// GameShareColors defines the emoji that each group is coloured with, in the order of the puzzle's groups
+var GameShareColors = []string{"🟨", "🟩", "🟦", "🟪", "🟥", "🟧", "🟫", "⬜"}
+
+var _ Domain = (*GameShare)(nil)
+
+// GameShare defines a spoiler-free summary of a completed game. Each row is an attempt where every block is coloured
+// by the group it belongs to, so neither the values nor the descriptions of the puzzle are revealed
+type GameShare struct {
+	PuzzleID string `json:"puzzle_id"`
+	Score    int8   `json:"score"`
+	// MaxScore defines the number of groups in the puzzle
+	MaxScore int `json:"max_score"`
+	Attempts int `json:"attempts"`
+
+	Rows []string `json:"rows"`
+	// Text defines the rows, along with the puzzle, score, and, attempts, formatted to be pasted as-is
+	Text string `json:"text"`
+}
+
+// NewGameShare builds the shareable summary of the given game
+//
+// NOTE: `game.Puzzle` must be loaded with its groups and blocks
+func NewGameShare(game Game) GameShare {
+	// Map each block to the color of the group it belongs to
+	colors := make(map[string]string, 0)
+	for i, group := range game.Puzzle.Groups {
+		for _, block := range group.Blocks {
+			colors[block.ID] = GameShareColors[i%len(GameShareColors)]
+		}
+	}
+
+	rows := make([]string, 0, len(game.Attempts))
+	for _, attempt := range game.Attempts {
+		var row strings.Builder
+		for _, block := range attempt {
+			row.WriteString(colors[block])
+		}
+
+		rows = append(rows, row.String())
+	}
+
+	share := GameShare{
+		PuzzleID: game.PuzzleID,
+		Score:    game.Score,
+		MaxScore: len(game.Puzzle.Groups),
+		Attempts: len(game.Attempts),
+
+		Rows: rows,
+	}
+
+	lines := []string{
+		fmt.Sprintf("Puzzlely %s", share.PuzzleID),
+		fmt.Sprintf("Score %d/%d in %d attempts", share.Score, share.MaxScore, share.Attempts),
+		"",
+	}
+	share.Text = strings.Join(append(lines, rows...), "\n")
+
+	return share
+}
+
+func (g GameShare) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.PuzzleID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&g.Score, validation.Min(int8(0)), validation.Max(int8(g.MaxScore))),
+		validation.Field(&g.MaxScore, validation.Required),
+		validation.Field(&g.Attempts, validation.Min(len(g.Rows)), validation.Max(len(g.Rows))),
+
+		validation.Field(&g.Rows, validation.Each(validation.Required)),
+		validation.Field(&g.Text, validation.Required),
+	)
+}
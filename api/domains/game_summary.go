@@ -14,24 +14,30 @@ var _ Domain = (*GameSummary)(nil)
 type GameSummary struct {
 	bun.BaseModel `bun:"table:games"`
 
-	ID       string `bun:"type:varchar(26),pk,notnull" json:"id"`
-	Score    int8   `bun:",notnull" json:"score"`
-	Attempts int16  `bun:",scanonly" json:"attempts"`
+	ID    string `bun:"type:varchar(26),pk,notnull" json:"id"`
+	Score int8   `bun:",notnull" json:"score"`
+	// Attempts defines the number of attempts that didn't match a group
+	Attempts int16 `bun:",scanonly" json:"attempts"`
+	// Hints defines the number of hints that were used. Each one has already been taken off of `Score`
+	Hints int16 `bun:",scanonly" json:"hints"`
 
 	CreatedAt   time.Time    `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
 	CompletedAt bun.NullTime `bun:",nullzero,default:NULL" json:"completed_at"`
 
-	PuzzleID string         `bun:"type:varchar(26),notnull" json:"-"`
-	Puzzle   PuzzleSummary  `bun:"rel:has-one,join:puzzle_id=id" json:"puzzle"`
-	UserID   sql.NullString `bun:"type:varchar(26)" json:"-"`
-	User     *User          `bun:"rel:belongs-to,join:user_id=id" json:"user"`
+	PuzzleID string `bun:"type:varchar(26),notnull" json:"-"`
+	// PuzzleVersion defines the version of the puzzle that the game was started on. `Puzzle` reflects this version
+	PuzzleVersion int            `bun:",notnull,default:1" json:"puzzle_version"`
+	Puzzle        PuzzleSummary  `bun:"rel:has-one,join:puzzle_id=id" json:"puzzle"`
+	UserID        sql.NullString `bun:"type:varchar(26)" json:"-"`
+	User          *User          `bun:"rel:belongs-to,join:user_id=id" json:"user"`
 }
 
 func (g GameSummary) Validate() error {
 	return validation.ValidateStruct(&g,
 		validation.Field(&g.ID, validation.Required, validation.By(internal.IsULID)),
-		validation.Field(&g.Score, validation.Max(int8(4)), validation.Min(int8(0))),
+		validation.Field(&g.Score, validation.Max(int8(g.Puzzle.GroupCount)), validation.Min(int8(0))),
 		validation.Field(&g.Attempts, validation.Max(g.Puzzle.MaxAttempts), validation.Min(int16(0))),
+		validation.Field(&g.Hints, validation.Min(int16(0))),
 
 		validation.Field(&g.CreatedAt, validation.Required),
 
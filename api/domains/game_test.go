@@ -0,0 +1,303 @@
+package domains
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Builds a puzzle with the given number of groups that each have 3 blocks
+func testPuzzle(groups int, maxAttempts int16) Puzzle {
+	puzzle := Puzzle{
+		ID:          ulid.Make().String(),
+		Difficulty:  "EASY",
+		MaxAttempts: maxAttempts,
+		GroupCount:  groups,
+		GroupSize:   3,
+	}
+	for i := range groups {
+		group := PuzzleGroup{
+			ID:          ulid.Make().String(),
+			Description: fmt.Sprintf("Group %d", i),
+		}
+		for j := range 3 {
+			group.Blocks = append(group.Blocks, PuzzleBlock{
+				ID:    ulid.Make().String(),
+				Value: fmt.Sprintf("Block %d-%d", i, j),
+			})
+		}
+
+		puzzle.Groups = append(puzzle.Groups, group)
+	}
+
+	return puzzle
+}
+
+// Returns the ids of the blocks at the given indexes of the puzzle's groups, e.g. [0, 0, 1] returns the first block of
+// the first group, the second block of the first group, and, the first block of the second group
+func testBlocks(puzzle Puzzle, groups ...int) []string {
+	ids := make([]string, 0, len(groups))
+	seen := make(map[int]int)
+	for _, group := range groups {
+		ids = append(ids, puzzle.Groups[group].Blocks[seen[group]].ID)
+		seen[group]++
+	}
+
+	return ids
+}
+
+func TestGameGuess(t *testing.T) {
+	puzzle := testPuzzle(3, 2)
+
+	solve := func(group int) []string {
+		return testBlocks(puzzle, group, group, group)
+	}
+	wrong := testBlocks(puzzle, 0, 0, 1)
+
+	tests := []struct {
+		name    string
+		guesses [][]string
+		// Error that the last guess is expected to return
+		err error
+
+		attempts  int
+		correct   int
+		completed bool
+	}{
+		{
+			name:     "correct guess",
+			guesses:  [][]string{solve(0)},
+			attempts: 1,
+			correct:  1,
+		},
+		{
+			name:     "wrong guess",
+			guesses:  [][]string{wrong},
+			attempts: 1,
+			correct:  0,
+		},
+		{
+			name:     "duplicate blocks",
+			guesses:  [][]string{{puzzle.Groups[0].Blocks[0].ID, puzzle.Groups[0].Blocks[0].ID, puzzle.Groups[0].Blocks[1].ID}},
+			err:      ErrGameInvalidGuess,
+			attempts: 0,
+		},
+		{
+			name:     "already solved group",
+			guesses:  [][]string{solve(0), solve(0)},
+			err:      ErrGameInvalidGuess,
+			attempts: 1,
+			correct:  1,
+		},
+		{
+			name:     "unknown block",
+			guesses:  [][]string{{puzzle.Groups[0].Blocks[0].ID, puzzle.Groups[0].Blocks[1].ID, puzzle.ID}},
+			err:      ErrGameInvalidGuess,
+			attempts: 0,
+		},
+		{
+			name:     "too few blocks",
+			guesses:  [][]string{testBlocks(puzzle, 0, 0)},
+			err:      ErrGameInvalidGuessSize,
+			attempts: 0,
+		},
+		{
+			name:      "reaching max attempts",
+			guesses:   [][]string{wrong, solve(2), wrong},
+			attempts:  3,
+			correct:   1,
+			completed: true,
+		},
+		{
+			name:      "guessing after max attempts",
+			guesses:   [][]string{wrong, wrong, solve(0)},
+			err:       ErrGameCompleted,
+			attempts:  2,
+			correct:   0,
+			completed: true,
+		},
+		{
+			name:      "last group is solved automatically",
+			guesses:   [][]string{solve(2), wrong, solve(0)},
+			attempts:  4,
+			correct:   3,
+			completed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame()
+			game.Puzzle = puzzle
+
+			var err error
+			for _, guess := range tt.guesses {
+				err = game.Guess(guess)
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if len(game.Attempts) != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, len(game.Attempts))
+			}
+			if len(game.Correct) != tt.correct {
+				t.Errorf("expected %d correct groups, got %d", tt.correct, len(game.Correct))
+			}
+			if int(game.Score) != tt.correct {
+				t.Errorf("expected a score of %d, got %d", tt.correct, game.Score)
+			}
+			if game.CompletedAt.IsZero() == tt.completed {
+				t.Errorf("expected completed to be %t", tt.completed)
+			}
+		})
+	}
+}
+
+func TestGameGiveUp(t *testing.T) {
+	puzzle := testPuzzle(3, 2)
+
+	game := NewGame()
+	game.Puzzle = puzzle
+	if err := game.Guess(testBlocks(puzzle, 0, 0, 0)); err != nil {
+		t.Fatalf("failed to guess: %v", err)
+	}
+
+	game.GiveUp()
+	if game.CompletedAt.IsZero() {
+		t.Fatal("expected game to be completed")
+	}
+	if len(game.Attempts) != 1 || len(game.Correct) != 1 || game.Score != 1 {
+		t.Fatalf("expected giving up to keep the game's progress, got %d attempts, %d correct, and, a score of %d", len(game.Attempts), len(game.Correct), game.Score)
+	}
+
+	completedAt := game.CompletedAt
+	game.GiveUp()
+	if !game.CompletedAt.Equal(completedAt.Time) {
+		t.Fatal("expected giving up twice to keep the first completion")
+	}
+
+	if err := game.Guess(testBlocks(puzzle, 1, 1, 1)); !errors.Is(err, ErrGameCompleted) {
+		t.Fatalf("expected error %v, got %v", ErrGameCompleted, err)
+	}
+}
+
+func TestGameHint(t *testing.T) {
+	puzzle := testPuzzle(3, 4)
+	puzzle.Groups[1].Hint = "Second group"
+
+	type hint struct {
+		hintType string
+		// Group that the hint is expected to be for
+		group int
+		err   error
+	}
+
+	tests := []struct {
+		name    string
+		guesses [][]string
+		hints   []hint
+
+		score int
+	}{
+		{
+			name:  "block hint reveals the first block of the first unsolved group",
+			hints: []hint{{hintType: GameHintTypeBlock, group: 0}},
+		},
+		{
+			name:  "hint skips groups without a hint",
+			hints: []hint{{hintType: GameHintTypeHint, group: 1}},
+		},
+		{
+			name:    "solved groups are skipped",
+			guesses: [][]string{testBlocks(puzzle, 0, 0, 0)},
+			hints:   []hint{{hintType: GameHintTypeBlock, group: 1}},
+			score:   0,
+		},
+		{
+			name:  "each group reveals a hint of a type once",
+			hints: []hint{{hintType: GameHintTypeBlock, group: 0}, {hintType: GameHintTypeBlock, group: 1}, {hintType: GameHintTypeHint, group: 1}},
+		},
+		{
+			name:  "no hints left",
+			hints: []hint{{hintType: GameHintTypeHint, group: 1}, {hintType: GameHintTypeHint, err: ErrGameNoHints}},
+		},
+		{
+			name:    "completed game",
+			guesses: [][]string{testBlocks(puzzle, 0, 0, 0), testBlocks(puzzle, 1, 1, 1)},
+			hints:   []hint{{hintType: GameHintTypeBlock, err: ErrGameCompleted}},
+			score:   3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame()
+			game.Puzzle = puzzle
+
+			for _, guess := range tt.guesses {
+				if err := game.Guess(guess); err != nil {
+					t.Fatalf("failed to guess: %v", err)
+				}
+			}
+
+			for _, h := range tt.hints {
+				used, err := game.Hint(h.hintType)
+				if !errors.Is(err, h.err) {
+					t.Fatalf("expected error %v, got %v", h.err, err)
+				}
+				if h.err != nil {
+					continue
+				}
+
+				group := puzzle.Groups[h.group]
+				if used.PuzzleGroupID != group.ID {
+					t.Errorf("expected a hint for group %d, got %s", h.group, used.PuzzleGroupID)
+				}
+				if h.hintType == GameHintTypeBlock && used.PuzzleBlockID != group.Blocks[0].ID {
+					t.Errorf("expected the first block of group %d to be revealed, got %s", h.group, used.PuzzleBlockID)
+				}
+				if h.hintType == GameHintTypeHint && used.PuzzleBlockID != "" {
+					t.Errorf("expected no block to be revealed, got %s", used.PuzzleBlockID)
+				}
+			}
+
+			if int(game.Score) != tt.score {
+				t.Errorf("expected a score of %d, got %d", tt.score, game.Score)
+			}
+		})
+	}
+}
+
+func TestGameHintScore(t *testing.T) {
+	puzzle := testPuzzle(4, 4)
+
+	game := NewGame()
+	game.Puzzle = puzzle
+
+	steps := []struct {
+		hint  bool
+		guess int
+		score int8
+	}{
+		{hint: true, score: 0},
+		{guess: 0, score: 0},
+		{guess: 1, score: 1},
+		{hint: true, score: 0},
+		// Solving the third group solves the last one too
+		{guess: 2, score: 2},
+	}
+	for i, step := range steps {
+		if step.hint {
+			if _, err := game.Hint(GameHintTypeBlock); err != nil {
+				t.Fatalf("step %d: failed to use hint: %v", i, err)
+			}
+		} else if err := game.Guess(testBlocks(puzzle, step.guess, step.guess, step.guess)); err != nil {
+			t.Fatalf("step %d: failed to guess: %v", i, err)
+		}
+
+		if game.Score != step.score {
+			t.Fatalf("step %d: expected a score of %d, got %d", i, step.score, game.Score)
+		}
+	}
+}
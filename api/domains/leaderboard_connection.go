@@ -0,0 +1,55 @@
+package domains
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*LeaderboardConnection)(nil)
+
+type LeaderboardConnection struct {
+	Edges    []LeaderboardEdge `json:"edges"`
+	PageInfo PageInfo          `json:"page_info"`
+	// Me defines the currently authenticated user's entry, if they've completed the puzzle, regardless of whether it's on
+	// the current page
+	Me *LeaderboardEntry `json:"me"`
+}
+
+func BuildLeaderboardConnection(nodes []LeaderboardEntry, limit int, me *LeaderboardEntry) (*LeaderboardConnection, error) {
+	edges := make([]LeaderboardEdge, 0)
+	for _, node := range nodes {
+		edges = append(edges, LeaderboardEdge{
+			Cursor: NewLeaderboardCursor(node),
+			Node:   node,
+		})
+	}
+
+	pageInfo := PageInfo{
+		HasNextPage:     len(edges) > limit,
+		HasPreviousPage: false,
+		NextCursor:      "",
+		PreviousCursor:  "",
+	}
+	if pageInfo.HasNextPage {
+		pageInfo.NextCursor = edges[len(edges)-1].Cursor
+		edges = edges[:len(edges)-1]
+	}
+
+	connection := LeaderboardConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+		Me:       me,
+	}
+	if err := connection.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
+func (l LeaderboardConnection) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Edges, validation.NotNil),
+		validation.Field(&l.PageInfo, validation.Required),
+		validation.Field(&l.Me, validation.When(l.Me != nil, validation.Required)),
+	)
+}
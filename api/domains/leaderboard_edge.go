@@ -0,0 +1,18 @@
+package domains
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+var _ Domain = (*LeaderboardEdge)(nil)
+
+// LeaderboardEdge defines a paginated leaderboard list item
+type LeaderboardEdge struct {
+	Cursor Cursor           `json:"cursor"`
+	Node   LeaderboardEntry `json:"node"`
+}
+
+func (l LeaderboardEdge) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Cursor, validation.Required),
+		validation.Field(&l.Node, validation.Required),
+	)
+}
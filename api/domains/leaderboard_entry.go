@@ -0,0 +1,58 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/uptrace/bun"
+)
+
+var _ Domain = (*LeaderboardEntry)(nil)
+
+// LeaderboardEntry defines a completed game's placement on a puzzle's leaderboard. Games are ranked by score, then by
+// number of attempts, then by solve time, with untimed games after timed ones. Hints are taken off of the score so
+// solves that used them rank lower
+type LeaderboardEntry struct {
+	bun.BaseModel `bun:"table:games,alias:leaderboard_entry"`
+
+	ID   string `bun:"type:varchar(26),pk,notnull" json:"id"`
+	Rank int    `bun:",scanonly" json:"rank"`
+
+	Score    int8  `bun:",notnull" json:"score"`
+	Attempts int16 `bun:",scanonly" json:"attempts"`
+	Hints    int16 `bun:",scanonly" json:"hints"`
+	// Timed defines whether the game was saved before it was completed. Games that were created and completed in the
+	// same save, e.g. by sending every attempt at once, don't have a meaningful solve time
+	Timed bool `bun:",scanonly" json:"timed"`
+	// SolveTime defines how long, in milliseconds, it took to complete the game
+	SolveTime int64 `bun:",scanonly" json:"solve_time"`
+
+	CreatedAt   time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+	CompletedAt time.Time `bun:",nullzero,notnull" json:"completed_at"`
+
+	// PuzzleVersion defines the version of the puzzle that the game was started on
+	PuzzleVersion int `bun:",notnull" json:"puzzle_version"`
+
+	PuzzleID string `bun:"type:varchar(26),notnull" json:"-"`
+	UserID   string `bun:"type:varchar(26),notnull" json:"-"`
+	User     User   `bun:"rel:belongs-to,join:user_id=id" json:"user"`
+}
+
+func (l LeaderboardEntry) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&l.Rank, validation.Required, validation.Min(1)),
+
+		validation.Field(&l.Score, validation.Max(int8(PuzzleMaxGroupCount)), validation.Min(int8(0))),
+		validation.Field(&l.Attempts, validation.Min(int16(0))),
+		validation.Field(&l.Hints, validation.Min(int16(0))),
+		validation.Field(&l.SolveTime, validation.Min(int64(0))),
+
+		validation.Field(&l.CreatedAt, validation.Required),
+		validation.Field(&l.CompletedAt, validation.Required),
+
+		validation.Field(&l.UserID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&l.User, validation.Required),
+	)
+}
@@ -0,0 +1,60 @@
+package domains
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	leaderboardCursorSeparator = ","
+)
+
+var _ Domain = (*LeaderboardOpts)(nil)
+
+// LeaderboardOpts defines the pagination for a puzzle's leaderboard
+type LeaderboardOpts struct {
+	Cursor Cursor `json:"-"`
+	Limit  int    `json:"-"`
+}
+
+// NewLeaderboardCursor creates a cursor from every field that entries are ordered by
+func NewLeaderboardCursor(node LeaderboardEntry) Cursor {
+	return NewCursor(strings.Join([]string{
+		strconv.Itoa(int(node.Score)),
+		strconv.Itoa(int(node.Attempts)),
+		strconv.FormatBool(node.Timed),
+		strconv.FormatInt(node.SolveTime, 10),
+		node.ID,
+	}, leaderboardCursorSeparator))
+}
+
+// DecodeCursor decodes the cursor into an entry with only the fields that it was created from
+func (l LeaderboardOpts) DecodeCursor() (LeaderboardEntry, error) {
+	decoded, err := l.Cursor.Decode()
+	if err != nil {
+		return LeaderboardEntry{}, err
+	}
+
+	parts := strings.Split(decoded, leaderboardCursorSeparator)
+	if len(parts) != 5 {
+		return LeaderboardEntry{}, ErrCursorInvalid
+	}
+
+	var entry LeaderboardEntry
+	if _, err := fmt.Sscanf(strings.Join(parts[:4], " "), "%d %d %t %d", &entry.Score, &entry.Attempts, &entry.Timed, &entry.SolveTime); err != nil {
+		return LeaderboardEntry{}, ErrCursorInvalid
+	}
+	entry.ID = parts[4]
+
+	return entry, nil
+}
+
+func (l LeaderboardOpts) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Cursor),
+		validation.Field(&l.Limit, validation.Min(1), validation.Max(99)),
+	)
+}
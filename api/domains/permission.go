@@ -0,0 +1,46 @@
+package domains
+
+import "slices"
+
+// Permission defines an action that only some roles are allowed to take
+type Permission string
+
+const (
+	// PermissionReport allows reporting puzzles and users
+	PermissionReport Permission = "REPORT"
+	// PermissionCreatePuzzles allows creating, importing, and editing puzzles
+	PermissionCreatePuzzles Permission = "CREATE_PUZZLES"
+	// PermissionReviewReports allows reviewing reports and acting on them
+	PermissionReviewReports Permission = "REVIEW_REPORTS"
+	// PermissionManageDaily allows scheduling daily puzzles
+	PermissionManageDaily Permission = "MANAGE_DAILY"
+	// PermissionManageRoles allows changing the role of other users
+	PermissionManageRoles Permission = "MANAGE_ROLES"
+)
+
+// UserRoles defines the roles that a user can have, from least to most privileged. Every role is granted the permissions
+// of the roles before it
+var UserRoles = []interface{}{"PLAYER", "CREATOR", "MODERATOR", "ADMIN"}
+
+// Permissions that each role adds on top of the roles before it
+var rolePermissions = map[string][]Permission{
+	"PLAYER":    {PermissionReport},
+	"CREATOR":   {PermissionCreatePuzzles},
+	"MODERATOR": {PermissionReviewReports},
+	"ADMIN":     {PermissionManageDaily, PermissionManageRoles},
+}
+
+// RolePermissions returns every permission that is granted to the given role. Unknown roles aren't granted anything
+func RolePermissions(role string) []Permission {
+	index := slices.Index(UserRoles, interface{}(role))
+	if index == -1 {
+		return []Permission{}
+	}
+
+	permissions := make([]Permission, 0)
+	for _, inherited := range UserRoles[:index+1] {
+		permissions = append(permissions, rolePermissions[inherited.(string)]...)
+	}
+
+	return permissions
+}
@@ -0,0 +1,99 @@
+package domains
+
+import (
+	"slices"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/uptrace/bun"
+)
+
+var _ Domain = (*PlayerGame)(nil)
+
+// PlayerGame defines the player-facing representation of a game. The puzzle is the player-facing representation of the
+// puzzle so that the groups that haven't been solved yet aren't revealed while the game is being played
+type PlayerGame struct {
+	ID       string     `json:"id"`
+	Score    int8       `json:"score"`
+	Attempts [][]string `json:"attempts"`
+	Correct  []string   `json:"correct"`
+	// Hints defines the hints that were used, in the order that they were used
+	Hints []PlayerGameHint `json:"hints"`
+
+	CreatedAt   time.Time    `json:"created_at"`
+	CompletedAt bun.NullTime `json:"completed_at"`
+
+	// PuzzleVersion defines the version of the puzzle that the game was started on
+	PuzzleVersion int          `json:"puzzle_version"`
+	Puzzle        PlayerPuzzle `json:"puzzle"`
+	User          User         `json:"user"`
+}
+
+// PlayerGameHint defines a hint that was used in a game along with what it revealed
+type PlayerGameHint struct {
+	Type string `json:"type"`
+
+	PuzzleGroupID string `json:"puzzle_group_id"`
+	// PuzzleBlockID defines the block that was revealed. This is only set for `GameHintTypeBlock`
+	PuzzleBlockID string `json:"puzzle_block_id,omitempty"`
+	// Hint defines the creator's hint that was revealed. This is only set for `GameHintTypeHint`
+	Hint string `json:"hint,omitempty"`
+}
+
+// NewPlayerGame builds the player-facing representation of the given game. Only the groups that the player has solved
+// are included in the puzzle
+//
+// NOTE: `game.Puzzle` must be loaded with its groups and blocks
+func NewPlayerGame(game Game) PlayerGame {
+	hints := make([]PlayerGameHint, 0, len(game.Hints))
+	for _, hint := range game.Hints {
+		playerHint := PlayerGameHint{
+			Type: hint.Type,
+
+			PuzzleGroupID: hint.PuzzleGroupID,
+			PuzzleBlockID: hint.PuzzleBlockID,
+		}
+		if hint.Type == GameHintTypeHint {
+			index := slices.IndexFunc(game.Puzzle.Groups, func(group PuzzleGroup) bool {
+				return group.ID == hint.PuzzleGroupID
+			})
+			if index != -1 {
+				playerHint.Hint = game.Puzzle.Groups[index].Hint
+			}
+		}
+
+		hints = append(hints, playerHint)
+	}
+
+	return PlayerGame{
+		ID:       game.ID,
+		Score:    game.Score,
+		Attempts: game.Attempts,
+		Correct:  game.Correct,
+		Hints:    hints,
+
+		CreatedAt:   game.CreatedAt,
+		CompletedAt: game.CompletedAt,
+
+		PuzzleVersion: game.PuzzleVersion,
+		Puzzle:        NewPlayerPuzzle(game.Puzzle, game.Correct),
+		User:          game.User,
+	}
+}
+
+func (p PlayerGame) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&p.Score, validation.Min(int8(0)), validation.Max(int8(p.Puzzle.GroupCount))),
+		validation.Field(&p.Attempts, validation.Each(validation.Required, validation.Length(p.Puzzle.GroupSize, p.Puzzle.GroupSize))),
+		validation.Field(&p.Correct, validation.Length(0, p.Puzzle.GroupCount)),
+		validation.Field(&p.Hints, validation.Each(validation.Required)),
+
+		validation.Field(&p.CreatedAt, validation.Required),
+
+		validation.Field(&p.PuzzleVersion, validation.Required, validation.Min(1)),
+		validation.Field(&p.Puzzle, validation.Required),
+		validation.Field(&p.User, validation.Required),
+	)
+}
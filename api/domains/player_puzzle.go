@@ -0,0 +1,123 @@
+package domains
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/uptrace/bun"
+)
+
+var _ Domain = (*PlayerPuzzle)(nil)
+
+// PlayerPuzzle defines the player-facing representation of a puzzle. Blocks are shuffled and don't reveal the group
+// they belong to, and only the groups that the player has solved are included
+type PlayerPuzzle struct {
+	ID          string `json:"id"`
+	Difficulty  string `json:"difficulty"`
+	MaxAttempts int16  `json:"max_attempts"`
+	Version     int    `json:"version"`
+	GroupCount  int    `json:"group_count"`
+	GroupSize   int    `json:"group_size"`
+	Language    string `json:"language"`
+	Tags        []Tag  `json:"tags,omitempty"`
+
+	Blocks []PlayerPuzzleBlock `json:"blocks"`
+	// Groups defines the groups that the player has solved, in the order that they were solved
+	Groups []PlayerPuzzleGroup `json:"groups"`
+
+	LikedAt    bun.NullTime `json:"liked_at"`
+	NumOfLikes int          `json:"num_of_likes"`
+
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt bun.NullTime `json:"updated_at"`
+
+	CreatedBy User `json:"created_by"`
+}
+
+// NewPlayerPuzzle builds the player-facing representation of the given puzzle. `correct` defines the ids of the groups
+// that the player has solved
+//
+// NOTE: `puzzle` must be loaded with its groups and blocks
+func NewPlayerPuzzle(puzzle Puzzle, correct []string) PlayerPuzzle {
+	blocks := make([]PlayerPuzzleBlock, 0)
+	for _, group := range puzzle.Groups {
+		for _, block := range group.Blocks {
+			blocks = append(blocks, PlayerPuzzleBlock{
+				ID:    block.ID,
+				Value: block.Value,
+			})
+		}
+	}
+	rand.Shuffle(len(blocks), func(i, j int) {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	})
+
+	groups := make([]PlayerPuzzleGroup, 0)
+	for _, id := range correct {
+		index := slices.IndexFunc(puzzle.Groups, func(group PuzzleGroup) bool {
+			return group.ID == id
+		})
+		if index == -1 {
+			continue
+		}
+
+		group := PlayerPuzzleGroup{
+			ID:          puzzle.Groups[index].ID,
+			Description: puzzle.Groups[index].Description,
+			Blocks:      make([]string, 0),
+		}
+		for _, block := range puzzle.Groups[index].Blocks {
+			group.Blocks = append(group.Blocks, block.ID)
+		}
+
+		groups = append(groups, group)
+	}
+
+	return PlayerPuzzle{
+		ID:          puzzle.ID,
+		Difficulty:  puzzle.Difficulty,
+		MaxAttempts: puzzle.MaxAttempts,
+		Version:     puzzle.Version,
+		GroupCount:  puzzle.GroupCount,
+		GroupSize:   puzzle.GroupSize,
+		Language:    puzzle.Language,
+		Tags:        puzzle.Tags,
+
+		Blocks: blocks,
+		Groups: groups,
+
+		LikedAt:    puzzle.LikedAt,
+		NumOfLikes: puzzle.NumOfLikes,
+
+		CreatedAt: puzzle.CreatedAt,
+		UpdatedAt: puzzle.UpdatedAt,
+
+		CreatedBy: puzzle.CreatedBy,
+	}
+}
+
+func (p PlayerPuzzle) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&p.Difficulty, validation.Required, validation.In("EASY", "MEDIUM", "HARD")),
+		validation.Field(&p.MaxAttempts, validation.Required, validation.Min(1), validation.Max(999)),
+		validation.Field(&p.GroupCount, validation.Required, validation.Min(PuzzleMinGroupCount), validation.Max(PuzzleMaxGroupCount)),
+		validation.Field(&p.GroupSize, validation.Required, validation.Min(PuzzleMinGroupSize), validation.Max(PuzzleMaxGroupSize)),
+		validation.Field(&p.Language, validation.Required, validation.In(PuzzleLanguages...)),
+		validation.Field(&p.Tags, validation.Length(0, PuzzleMaxTags)),
+
+		validation.Field(&p.Blocks, validation.Required, validation.Length(p.GroupCount*p.GroupSize, p.GroupCount*p.GroupSize), validation.Each(validation.Required)),
+		validation.Field(&p.Groups, validation.NotNil, validation.Length(0, p.GroupCount), validation.Each(validation.Required)),
+
+		validation.Field(&p.LikedAt, validation.When(!p.LikedAt.IsZero(), validation.By(internal.IsAfter(p.CreatedAt)))),
+		validation.Field(&p.NumOfLikes, validation.Min(0)),
+
+		validation.Field(&p.CreatedAt, validation.Required),
+		validation.Field(&p.UpdatedAt, validation.When(!p.UpdatedAt.IsZero(), validation.By(internal.IsAfter(p.CreatedAt)))),
+
+		validation.Field(&p.CreatedBy, validation.Required),
+	)
+}
@@ -0,0 +1,21 @@
+package domains
+
+import (
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*PlayerPuzzleBlock)(nil)
+
+// PlayerPuzzleBlock defines a block without the group that it belongs to
+type PlayerPuzzleBlock struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+func (p PlayerPuzzleBlock) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&p.Value, validation.Required, internal.GraphemeLength(1, 48)),
+	)
+}
@@ -0,0 +1,41 @@
+package domains
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*PlayerPuzzleConnection)(nil)
+
+type PlayerPuzzleConnection struct {
+	Edges    []PlayerPuzzleEdge `json:"edges"`
+	PageInfo PageInfo           `json:"page_info"`
+}
+
+// BuildPlayerPuzzleConnection builds the player-facing representation of the given connection. `correct` maps a
+// puzzle's id to the ids of the groups that the player has solved
+func BuildPlayerPuzzleConnection(connection PuzzleConnection, correct map[string][]string) (*PlayerPuzzleConnection, error) {
+	edges := make([]PlayerPuzzleEdge, 0)
+	for _, edge := range connection.Edges {
+		edges = append(edges, PlayerPuzzleEdge{
+			Cursor: edge.Cursor,
+			Node:   NewPlayerPuzzle(edge.Node, correct[edge.Node.ID]),
+		})
+	}
+
+	playerConnection := PlayerPuzzleConnection{
+		Edges:    edges,
+		PageInfo: connection.PageInfo,
+	}
+	if err := playerConnection.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &playerConnection, nil
+}
+
+func (p PlayerPuzzleConnection) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Edges, validation.NotNil),
+		validation.Field(&p.PageInfo, validation.Required),
+	)
+}
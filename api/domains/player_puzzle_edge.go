@@ -0,0 +1,18 @@
+package domains
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+var _ Domain = (*PlayerPuzzleEdge)(nil)
+
+// PlayerPuzzleEdge defines a paginated player puzzle list item
+type PlayerPuzzleEdge struct {
+	Cursor Cursor       `json:"cursor"`
+	Node   PlayerPuzzle `json:"node"`
+}
+
+func (p PlayerPuzzleEdge) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Cursor, validation.Required),
+		validation.Field(&p.Node, validation.Required),
+	)
+}
@@ -0,0 +1,24 @@
+package domains
+
+import (
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*PlayerPuzzleGroup)(nil)
+
+// PlayerPuzzleGroup defines a group that the player has solved
+type PlayerPuzzleGroup struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	// Blocks defines the ids of the blocks in the group
+	Blocks []string `json:"blocks"`
+}
+
+func (p PlayerPuzzleGroup) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&p.Description, validation.Required, internal.GraphemeLength(1, 512)),
+		validation.Field(&p.Blocks, validation.Required, validation.Length(PuzzleMinGroupSize, PuzzleMaxGroupSize), validation.Each(validation.By(internal.IsULID))),
+	)
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/RagOfJoes/puzzlely/internal"
 	"github.com/go-chi/render"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
 var _ Domain = (*PuzzleUpdatePayload)(nil)
@@ -22,24 +21,33 @@ type PuzzleUpdatePayloadGroup struct {
 func (p PuzzleUpdatePayloadGroup) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
-		validation.Field(&p.Description, validation.Required, validation.Length(1, 512), is.PrintableASCII, internal.IsSanitized, internal.IsClean),
+		validation.Field(&p.Description, validation.Required, internal.GraphemeLength(1, 512), internal.IsPrintable, internal.IsSanitized, internal.IsClean),
 	)
 }
 
 type PuzzleUpdatePayload struct {
 	Difficulty string `json:"difficulty"`
+	// Tags replaces the names of the puzzle's tags. Leaving it out keeps the puzzle's current tags
+	Tags []string `json:"tags"`
 
 	Groups []PuzzleUpdatePayloadGroup `json:"groups"`
 }
 
+// Normalizes descriptions and tags so that they're validated and stored the same way as new puzzles
 func (p *PuzzleUpdatePayload) Bind(r *http.Request) error {
+	for i := range p.Groups {
+		p.Groups[i].Description = internal.Normalize(p.Groups[i].Description)
+	}
+	p.Tags = normalizeTags(p.Tags)
+
 	return nil
 }
 
 func (p PuzzleUpdatePayload) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.Difficulty, validation.Required, validation.In("EASY", "MEDIUM", "HARD")),
+		validation.Field(&p.Tags, validation.When(p.Tags != nil, validation.Length(0, PuzzleMaxTags), validation.Each(tagNameRules...))),
 
-		validation.Field(&p.Groups, validation.Required, validation.Length(0, 4), validation.Each(validation.Required)),
+		validation.Field(&p.Groups, validation.Required, validation.Length(0, PuzzleMaxGroupCount), validation.Each(validation.Required)),
 	)
 }
@@ -1,6 +1,7 @@
 package domains
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/RagOfJoes/puzzlely/internal"
@@ -8,6 +9,25 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// Bounds of a puzzle's grid. A puzzle is made up of `GroupCount` groups that each have `GroupSize` blocks
+const (
+	PuzzleDefaultGroupCount = 4
+	PuzzleDefaultGroupSize  = 4
+	PuzzleMinGroupCount     = 3
+	PuzzleMaxGroupCount     = 6
+	PuzzleMinGroupSize      = 3
+	PuzzleMaxGroupSize      = 6
+)
+
+// PuzzleDefaultLanguage defines the language that a puzzle is written in when one isn't given
+const PuzzleDefaultLanguage = "en"
+
+// PuzzleLanguages defines the languages, as ISO 639-1 codes, that puzzles can be written in
+var PuzzleLanguages = []interface{}{"de", "en", "es", "ja"}
+
+// PuzzleMaxTags defines the most tags that a puzzle can have
+const PuzzleMaxTags = 5
+
 var _ Domain = (*Puzzle)(nil)
 
 type Puzzle struct {
@@ -16,8 +36,18 @@ type Puzzle struct {
 	ID          string `bun:"type:varchar(26),pk,notnull" json:"id"`
 	Difficulty  string `bun:"type:varchar(12),default:'EASY',notnull" json:"difficulty"`
 	MaxAttempts int16  `bun:",notnull" json:"max_attempts"`
+	// Version defines the current version of the puzzle's content. It is incremented every time that the puzzle is edited
+	Version int `bun:",notnull,default:1" json:"version"`
+	// GroupCount defines the number of groups in the puzzle
+	GroupCount int `bun:"type:smallint,notnull,default:4" json:"group_count"`
+	// GroupSize defines the number of blocks in each group
+	GroupSize int `bun:"type:smallint,notnull,default:4" json:"group_size"`
+	// Language defines the language that the puzzle's content is written in
+	Language string `bun:"type:varchar(8),notnull,default:'en'" json:"language"`
 
 	Groups []PuzzleGroup `bun:"rel:has-many,join:id=puzzle_id" json:"groups"`
+	// Tags defines the topics that the creator attached to the puzzle
+	Tags []Tag `bun:"-" json:"tags,omitempty"`
 
 	LikedAt    bun.NullTime `bun:",scanonly" json:"liked_at"`
 	NumOfLikes int          `bun:",scanonly" json:"num_of_likes"`
@@ -25,18 +55,36 @@ type Puzzle struct {
 	CreatedAt time.Time    `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt bun.NullTime `bun:",nullzero,default:NULL" json:"updated_at"`
 	DeletedAt bun.NullTime `bun:",soft_delete,nullzero,default:NULL" json:"-"`
+	// HiddenAt defines when a moderator hid the puzzle. Hidden puzzles are left out of every listing, and, can only be
+	// seen by their creator and moderators. See `IsVisibleTo`
+	HiddenAt bun.NullTime `bun:",nullzero,default:NULL" json:"-"`
 
 	UserID    string `bun:"type:varchar(26),notnull" json:"-"`
 	CreatedBy User   `bun:"rel:belongs-to,join:user_id=id" json:"created_by"`
 }
 
+// IsVisibleTo checks whether the given user, which is nil for anonymous users, can see the puzzle. Hidden puzzles can
+// only be seen by their creator and moderators
+func (p Puzzle) IsVisibleTo(user *User) bool {
+	if p.HiddenAt.IsZero() {
+		return true
+	}
+
+	return user != nil && (user.ID == p.UserID || user.Can(PermissionReviewReports))
+}
+
 func (p Puzzle) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
 		validation.Field(&p.Difficulty, validation.Required, validation.In("EASY", "MEDIUM", "HARD")),
 		validation.Field(&p.MaxAttempts, validation.Required, validation.Min(1), validation.Max(999)),
+		validation.Field(&p.Version, validation.Required, validation.Min(1)),
+		validation.Field(&p.GroupCount, validation.Required, validation.Min(PuzzleMinGroupCount), validation.Max(PuzzleMaxGroupCount)),
+		validation.Field(&p.GroupSize, validation.Required, validation.Min(PuzzleMinGroupSize), validation.Max(PuzzleMaxGroupSize)),
+		validation.Field(&p.Language, validation.Required, validation.In(PuzzleLanguages...)),
 
-		validation.Field(&p.Groups, validation.Required, validation.Length(4, 4), validation.Each(validation.Required)),
+		validation.Field(&p.Groups, validation.Required, validation.Length(p.GroupCount, p.GroupCount), validation.Each(validation.Required, validation.By(p.isGroupSize))),
+		validation.Field(&p.Tags, validation.Length(0, PuzzleMaxTags)),
 
 		validation.Field(&p.LikedAt, validation.When(!p.LikedAt.IsZero(), validation.By(internal.IsAfter(p.CreatedAt)))),
 		validation.Field(&p.NumOfLikes, validation.Min(0)),
@@ -44,8 +92,50 @@ func (p Puzzle) Validate() error {
 		validation.Field(&p.CreatedAt, validation.Required),
 		validation.Field(&p.UpdatedAt, validation.When(!p.UpdatedAt.IsZero(), validation.By(internal.IsAfter(p.CreatedAt)))),
 		validation.Field(&p.DeletedAt, validation.When(!p.DeletedAt.IsZero(), validation.By(internal.IsAfter(p.CreatedAt)))),
+		validation.Field(&p.HiddenAt, validation.When(!p.HiddenAt.IsZero(), validation.By(internal.IsAfter(p.CreatedAt)))),
 
 		validation.Field(&p.UserID, validation.Required, validation.By(internal.IsULID)),
 		validation.Field(&p.CreatedBy, validation.Required),
 	)
 }
+
+// Checks that a group has as many blocks as the puzzle's `GroupSize`
+func (p Puzzle) isGroupSize(value interface{}) error {
+	group, ok := value.(PuzzleGroup)
+	if !ok || len(group.Blocks) != p.GroupSize {
+		return fmt.Errorf("must have %d blocks", p.GroupSize)
+	}
+
+	return nil
+}
+
+// ValidateContent checks the puzzle's group descriptions, hints, and, block values for dirty words in the puzzle's
+// language. This is kept out of `Validate` so that puzzles that were saved before a word was added can still be read
+func (p Puzzle) ValidateContent() error {
+	isClean := internal.IsCleanIn(p.Language)
+
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Tags, validation.Each(validation.By(func(value interface{}) error {
+			tag, _ := value.(Tag)
+
+			return validation.ValidateStruct(&tag,
+				validation.Field(&tag.Name, isClean),
+			)
+		})), validation.Skip),
+		validation.Field(&p.Groups, validation.Each(validation.By(func(value interface{}) error {
+			group, _ := value.(PuzzleGroup)
+
+			return validation.ValidateStruct(&group,
+				validation.Field(&group.Description, isClean),
+				validation.Field(&group.Hint, isClean),
+				validation.Field(&group.Blocks, validation.Each(validation.By(func(value interface{}) error {
+					block, _ := value.(PuzzleBlock)
+
+					return validation.ValidateStruct(&block,
+						validation.Field(&block.Value, isClean),
+					)
+				})), validation.Skip),
+			)
+		})), validation.Skip),
+	)
+}
@@ -3,7 +3,6 @@ package domains
 import (
 	"github.com/RagOfJoes/puzzlely/internal"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/uptrace/bun"
 )
 
@@ -12,8 +11,9 @@ var _ Domain = (*PuzzleBlock)(nil)
 type PuzzleBlock struct {
 	bun.BaseModel
 
-	ID    string `bun:"type:varchar(26),pk,notnull" json:"id"`
-	Value string `bun:"type:varchar(48),notnull" json:"value"`
+	ID string `bun:"type:varchar(26),pk,notnull" json:"id"`
+	// Value is limited to 48 graphemes but emoji, and combining characters, can take up more than one code point each
+	Value string `bun:"type:varchar(192),notnull" json:"value"`
 
 	PuzzleGroupID string `bun:"type:varchar(26),notnull" json:"puzzle_group_id"`
 }
@@ -21,7 +21,7 @@ type PuzzleBlock struct {
 func (p PuzzleBlock) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
-		validation.Field(&p.Value, validation.Required, validation.Length(1, 48), is.PrintableASCII),
+		validation.Field(&p.Value, validation.Required, validation.Length(1, 192), internal.GraphemeLength(1, 48), internal.IsPrintable),
 
 		validation.Field(&p.PuzzleGroupID, validation.Required, validation.By(internal.IsULID)),
 	)
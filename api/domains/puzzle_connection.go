@@ -37,6 +37,37 @@ func BuildPuzzleConnection(nodes []Puzzle) (*PuzzleConnection, error) {
 	return &connection, nil
 }
 
+func BuildPuzzleConnectionForDaily(nodes []DailyPuzzle, limit int) (*PuzzleConnection, error) {
+	edges := make([]PuzzleEdge, 0)
+	for _, node := range nodes {
+		edges = append(edges, PuzzleEdge{
+			Cursor: NewCursor(node.Date.Format(DailyPuzzleDateLayout)),
+			Node:   node.Puzzle,
+		})
+	}
+
+	pageInfo := PageInfo{
+		HasNextPage:     len(edges) > limit,
+		HasPreviousPage: false,
+		NextCursor:      "",
+		PreviousCursor:  "",
+	}
+	if pageInfo.HasNextPage {
+		pageInfo.NextCursor = edges[len(edges)-1].Cursor
+		edges = edges[:len(edges)-1]
+	}
+
+	connection := PuzzleConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}
+	if err := connection.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
 func (p PuzzleConnection) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.Edges, validation.NotNil),
@@ -2,6 +2,7 @@ package domains
 
 import (
 	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"strings"
 	"time"
@@ -9,7 +10,6 @@ import (
 	"github.com/RagOfJoes/puzzlely/internal"
 	"github.com/go-chi/render"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -25,25 +25,35 @@ type PuzzleCreatePayloadBlock struct {
 
 func (p PuzzleCreatePayloadBlock) Validate() error {
 	return validation.ValidateStruct(&p,
-		validation.Field(&p.Value, validation.Required, validation.Length(1, 48), is.PrintableASCII, internal.IsSanitized, internal.IsClean),
+		validation.Field(&p.Value, validation.Required, internal.GraphemeLength(1, 48), internal.IsPrintable, internal.IsSanitized, internal.IsClean),
 	)
 }
 
 type PuzzleCreatePayloadGroup struct {
 	Description string                     `json:"description"`
 	Blocks      []PuzzleCreatePayloadBlock `json:"blocks"`
+	// Hint is optional and can be revealed by players that are stuck on the group
+	Hint string `json:"hint,omitempty"`
 }
 
 func (p PuzzleCreatePayloadGroup) Validate() error {
 	return validation.ValidateStruct(&p,
-		validation.Field(&p.Description, validation.Required, validation.Length(1, 512), is.PrintableASCII, internal.IsSanitized, internal.IsClean),
-		validation.Field(&p.Blocks, validation.Required, validation.Length(4, 4), validation.Each(validation.Required)),
+		validation.Field(&p.Description, validation.Required, internal.GraphemeLength(1, 512), internal.IsPrintable, internal.IsSanitized, internal.IsClean),
+		validation.Field(&p.Hint, internal.GraphemeLength(0, 512), internal.IsPrintable, internal.IsSanitized, internal.IsClean),
+		validation.Field(&p.Blocks, validation.Required, validation.Length(PuzzleMinGroupSize, PuzzleMaxGroupSize), validation.Each(validation.Required)),
 	)
 }
 
+// PuzzleCreatePayload defines the payload for creating a puzzle. The size of the puzzle's grid is taken from its groups,
+// which must all have the same number of blocks. Text is normalized to NFC before it is validated, or, turned into a
+// puzzle
 type PuzzleCreatePayload struct {
 	Difficulty  string `json:"difficulty"`
 	MaxAttempts int16  `json:"max_attempts"`
+	// Language is optional and defaults to `PuzzleDefaultLanguage`
+	Language string `json:"language,omitempty"`
+	// Tags defines the names of the puzzle's tags. Names that share a slug are only kept once
+	Tags []string `json:"tags,omitempty"`
 
 	Groups []PuzzleCreatePayloadGroup `json:"groups"`
 }
@@ -53,25 +63,42 @@ func (p *PuzzleCreatePayload) Bind(r *http.Request) error {
 }
 
 func (p PuzzleCreatePayload) ToPuzzle() Puzzle {
+	p = p.normalize()
 	id := ulid.Make()
 
+	// ULIDs made within the same millisecond are sequential, so the block ids are shuffled to keep them from giving
+	// away which group a block belongs to
+	blockIDs := []string{}
+	for _, group := range p.Groups {
+		for range group.Blocks {
+			blockIDs = append(blockIDs, ulid.Make().String())
+		}
+	}
+	rand.Shuffle(len(blockIDs), func(i, j int) {
+		blockIDs[i], blockIDs[j] = blockIDs[j], blockIDs[i]
+	})
+
+	groupSize := 0
 	groups := []PuzzleGroup{}
 	for _, group := range p.Groups {
 		groupID := ulid.Make()
+		groupSize = max(groupSize, len(group.Blocks))
 
 		blocks := []PuzzleBlock{}
 		for _, block := range group.Blocks {
 			blocks = append(blocks, PuzzleBlock{
-				ID:    ulid.Make().String(),
+				ID:    blockIDs[0],
 				Value: block.Value,
 
 				PuzzleGroupID: groupID.String(),
 			})
+			blockIDs = blockIDs[1:]
 		}
 
 		groups = append(groups, PuzzleGroup{
 			ID:          groupID.String(),
 			Description: group.Description,
+			Hint:        group.Hint,
 
 			Blocks: blocks,
 
@@ -79,43 +106,91 @@ func (p PuzzleCreatePayload) ToPuzzle() Puzzle {
 		})
 	}
 
+	tags := make([]Tag, 0, len(p.Tags))
+	for _, name := range p.Tags {
+		tags = append(tags, NewTag(name))
+	}
+
 	return Puzzle{
 		ID:          id.String(),
 		Difficulty:  p.Difficulty,
 		MaxAttempts: p.MaxAttempts,
+		Version:     1,
+		GroupCount:  len(groups),
+		GroupSize:   groupSize,
+		Language:    p.Language,
 
 		Groups: groups,
+		Tags:   tags,
 
 		CreatedAt: time.Now(),
 	}
 }
 
 func (p PuzzleCreatePayload) Validate() error {
+	p = p.normalize()
 	if err := validation.ValidateStruct(&p,
 		validation.Field(&p.Difficulty, validation.Required, validation.In("EASY", "MEDIUM", "HARD")),
 		validation.Field(&p.MaxAttempts, validation.Required, validation.Min(1), validation.Max(999)),
+		validation.Field(&p.Language, validation.Required, validation.In(PuzzleLanguages...)),
+		validation.Field(&p.Tags, validation.Length(0, PuzzleMaxTags), validation.Each(tagNameRules...)),
 
-		validation.Field(&p.Groups, validation.Required, validation.Length(4, 4), validation.Each(validation.Required)),
+		validation.Field(&p.Groups, validation.Required, validation.Length(PuzzleMinGroupCount, PuzzleMaxGroupCount), validation.Each(validation.Required)),
 	); err != nil {
 		return err
 	}
 
+	for _, group := range p.Groups {
+		if len(group.Blocks) != len(p.Groups[0].Blocks) {
+			return fmt.Errorf("Groups must all have the same number of blocks. Found groups with %d and %d blocks.", len(p.Groups[0].Blocks), len(group.Blocks))
+		}
+	}
+
+	// Blocks that only differ by look-alike characters, e.g. a Latin "a" and a Cyrillic "а", are duplicates as well
 	duplicates := map[string][][2]int{}
 	for i, group := range p.Groups {
 		for j, block := range group.Blocks {
-			trimmed := strings.TrimSpace(block.Value)
+			skeleton := internal.Skeleton(block.Value)
 
-			duplicates[trimmed] = append(duplicates[trimmed], [2]int{i, j})
+			duplicates[skeleton] = append(duplicates[skeleton], [2]int{i, j})
 		}
 	}
 
-	for key, value := range duplicates {
+	for _, value := range duplicates {
 		if len(value) <= 1 {
 			continue
 		}
 
-		return fmt.Errorf("Blocks must all be unique. Found %d blocks with a duplicate value of \"%s\".", len(value), key)
+		return fmt.Errorf("Blocks must all be unique. Found %d blocks with a duplicate value of \"%s\".", len(value), p.Groups[value[0][0]].Blocks[value[0][1]].Value)
 	}
 
 	return nil
 }
+
+// Returns a copy of the payload with its text normalized, its tags deduplicated, and its language defaulted
+func (p PuzzleCreatePayload) normalize() PuzzleCreatePayload {
+	p.Language = strings.ToLower(strings.TrimSpace(p.Language))
+	if p.Language == "" {
+		p.Language = PuzzleDefaultLanguage
+	}
+	p.Tags = normalizeTags(p.Tags)
+
+	groups := make([]PuzzleCreatePayloadGroup, 0, len(p.Groups))
+	for _, group := range p.Groups {
+		blocks := make([]PuzzleCreatePayloadBlock, 0, len(group.Blocks))
+		for _, block := range group.Blocks {
+			blocks = append(blocks, PuzzleCreatePayloadBlock{
+				Value: internal.Normalize(block.Value),
+			})
+		}
+
+		groups = append(groups, PuzzleCreatePayloadGroup{
+			Description: internal.Normalize(group.Description),
+			Blocks:      blocks,
+			Hint:        internal.Normalize(group.Hint),
+		})
+	}
+	p.Groups = groups
+
+	return p
+}
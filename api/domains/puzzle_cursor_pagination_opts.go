@@ -8,6 +8,9 @@ type PuzzleCursorPaginationOpts struct {
 	Cursor    Cursor `json:"-"`
 	Direction string `json:"-"`
 	Limit     int    `json:"-"`
+
+	// Language only includes puzzles written in the given language. This is only used for recent puzzles
+	Language string `json:"-"`
 }
 
 func (p PuzzleCursorPaginationOpts) Validate() error {
@@ -15,5 +18,7 @@ func (p PuzzleCursorPaginationOpts) Validate() error {
 		validation.Field(&p.Cursor),
 		validation.Field(&p.Direction, validation.In("B", "F")),
 		validation.Field(&p.Limit, validation.Min(1), validation.Max(99)),
+
+		validation.Field(&p.Language, validation.When(p.Language != "", validation.In(PuzzleLanguages...))),
 	)
 }
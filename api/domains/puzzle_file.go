@@ -0,0 +1,243 @@
+package domains
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Errors
+var (
+	ErrPuzzleFileCSVHeader = errors.New("CSV must start with the header: version, puzzle, difficulty, max_attempts, description, and, one column per block.")
+	ErrPuzzleFileVersion   = errors.New("Unsupported puzzle file version.")
+)
+
+// PuzzleFileVersion defines the current version of the puzzle file format. It must be bumped whenever a change to the
+// format would break older files
+const PuzzleFileVersion = 1
+
+// PuzzleFileMaxPuzzles defines the most puzzles that a single file can hold
+const PuzzleFileMaxPuzzles = 25
+
+// PuzzleFileCSVHeader defines the leading columns of the CSV variant. Every row is a group and is followed by one
+// column for each of the group's blocks. Rows that share the same `puzzle` label make up a single puzzle
+var PuzzleFileCSVHeader = []string{"version", "puzzle", "difficulty", "max_attempts", "description"}
+
+var _ Domain = (*PuzzleFile)(nil)
+
+// PuzzleFile defines the format that puzzles are imported from, and exported to, so that they can be written offline
+// and backed up
+type PuzzleFile struct {
+	Version int                   `json:"version"`
+	Puzzles []PuzzleCreatePayload `json:"puzzles"`
+}
+
+// NewPuzzleFile creates a file, in the current version, with the given puzzles
+//
+// NOTE: Puzzles must be loaded with their groups and blocks
+func NewPuzzleFile(puzzles ...Puzzle) PuzzleFile {
+	file := PuzzleFile{
+		Version: PuzzleFileVersion,
+		Puzzles: make([]PuzzleCreatePayload, 0, len(puzzles)),
+	}
+
+	for _, puzzle := range puzzles {
+		payload := PuzzleCreatePayload{
+			Difficulty:  puzzle.Difficulty,
+			MaxAttempts: puzzle.MaxAttempts,
+			Language:    puzzle.Language,
+
+			Groups: make([]PuzzleCreatePayloadGroup, 0, len(puzzle.Groups)),
+		}
+		for _, tag := range puzzle.Tags {
+			payload.Tags = append(payload.Tags, tag.Name)
+		}
+		for _, group := range puzzle.Groups {
+			blocks := make([]PuzzleCreatePayloadBlock, 0, len(group.Blocks))
+			for _, block := range group.Blocks {
+				blocks = append(blocks, PuzzleCreatePayloadBlock{
+					Value: block.Value,
+				})
+			}
+
+			payload.Groups = append(payload.Groups, PuzzleCreatePayloadGroup{
+				Description: group.Description,
+				Blocks:      blocks,
+				Hint:        group.Hint,
+			})
+		}
+
+		file.Puzzles = append(file.Puzzles, payload)
+	}
+
+	return file
+}
+
+// ParsePuzzleFileJSON reads a file in the JSON variant
+func ParsePuzzleFileJSON(r io.Reader) (PuzzleFile, error) {
+	var file PuzzleFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return PuzzleFile{}, err
+	}
+
+	return file, nil
+}
+
+// ParsePuzzleFileCSV reads a file in the CSV variant. Puzzles are kept in the order that their label first appears
+func ParsePuzzleFileCSV(r io.Reader) (PuzzleFile, error) {
+	reader := csv.NewReader(r)
+	// Groups can have any number of blocks
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil || len(header) <= len(PuzzleFileCSVHeader) {
+		return PuzzleFile{}, ErrPuzzleFileCSVHeader
+	}
+	for i, column := range PuzzleFileCSVHeader {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), column) {
+			return PuzzleFile{}, ErrPuzzleFileCSVHeader
+		}
+	}
+
+	file := PuzzleFile{
+		Puzzles: make([]PuzzleCreatePayload, 0),
+	}
+
+	labels := make(map[string]int, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return PuzzleFile{}, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) < len(PuzzleFileCSVHeader) {
+			return PuzzleFile{}, fmt.Errorf("Line %d is missing columns.", line)
+		}
+
+		version, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return PuzzleFile{}, fmt.Errorf("Line %d has an invalid version.", line)
+		}
+		// Every row must be in the same version
+		if file.Version != 0 && file.Version != version {
+			return PuzzleFile{}, fmt.Errorf("Line %d has a different version than the rows before it.", line)
+		}
+		file.Version = version
+
+		maxAttempts, err := strconv.ParseInt(strings.TrimSpace(record[3]), 10, 16)
+		if err != nil {
+			return PuzzleFile{}, fmt.Errorf("Line %d has an invalid max attempts.", line)
+		}
+
+		label := strings.TrimSpace(record[1])
+		index, ok := labels[label]
+		if !ok {
+			index = len(file.Puzzles)
+			labels[label] = index
+
+			file.Puzzles = append(file.Puzzles, PuzzleCreatePayload{
+				Difficulty:  strings.ToUpper(strings.TrimSpace(record[2])),
+				MaxAttempts: int16(maxAttempts),
+
+				Groups: make([]PuzzleCreatePayloadGroup, 0),
+			})
+		}
+
+		group := PuzzleCreatePayloadGroup{
+			Description: record[4],
+			Blocks:      make([]PuzzleCreatePayloadBlock, 0),
+		}
+		// Allow trailing empty columns so that groups with fewer blocks can share a file with bigger ones
+		for _, value := range record[len(PuzzleFileCSVHeader):] {
+			if value == "" {
+				continue
+			}
+
+			group.Blocks = append(group.Blocks, PuzzleCreatePayloadBlock{
+				Value: value,
+			})
+		}
+
+		file.Puzzles[index].Groups = append(file.Puzzles[index].Groups, group)
+	}
+
+	return file, nil
+}
+
+// WriteJSON writes the file in the JSON variant
+func (p PuzzleFile) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(p)
+}
+
+// WriteCSV writes the file in the CSV variant. Puzzles are labelled by their position in the file
+//
+// NOTE: Group hints, puzzle languages, and, tags are only kept by the JSON variant
+func (p PuzzleFile) WriteCSV(w io.Writer) error {
+	blocks := 0
+	for _, puzzle := range p.Puzzles {
+		for _, group := range puzzle.Groups {
+			blocks = max(blocks, len(group.Blocks))
+		}
+	}
+
+	header := append([]string{}, PuzzleFileCSVHeader...)
+	for i := range blocks {
+		header = append(header, fmt.Sprintf("block_%d", i+1))
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for i, puzzle := range p.Puzzles {
+		for _, group := range puzzle.Groups {
+			record := []string{
+				strconv.Itoa(p.Version),
+				strconv.Itoa(i + 1),
+				puzzle.Difficulty,
+				strconv.Itoa(int(puzzle.MaxAttempts)),
+				group.Description,
+			}
+			for _, block := range group.Blocks {
+				record = append(record, block.Value)
+			}
+			for len(record) < len(header) {
+				record = append(record, "")
+			}
+
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func (p PuzzleFile) Validate() error {
+	if p.Version != PuzzleFileVersion {
+		return ErrPuzzleFileVersion
+	}
+
+	return validation.ValidateStruct(&p,
+		// Each puzzle is validated on its own so that errors can be reported for every puzzle rather than the file
+		validation.Field(&p.Puzzles, validation.Required, validation.Length(1, PuzzleFileMaxPuzzles), validation.Skip),
+	)
+}
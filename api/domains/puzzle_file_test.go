@@ -0,0 +1,170 @@
+package domains
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePuzzleFileCSV(t *testing.T) {
+	const header = "version,puzzle,difficulty,max_attempts,description,block_1,block_2,block_3\n"
+
+	tests := []struct {
+		name string
+		csv  string
+		// Either `err` or `errMessage` is set when the file is expected to be rejected
+		err        error
+		errMessage string
+
+		version int
+		// Number of blocks in each group of each puzzle
+		blocks [][]int
+	}{
+		{
+			name:    "single puzzle",
+			csv:     header + "1,a,easy,4,First,a1,a2,a3\n1,a,easy,4,Second,b1,b2,b3\n",
+			version: 1,
+			blocks:  [][]int{{3, 3}},
+		},
+		{
+			name:    "puzzles are kept in the order that their label first appears",
+			csv:     header + "1,b,easy,4,First,a1,a2,a3\n1,a,hard,4,First,a1,a2,a3\n1,b,easy,4,Second,b1,b2,b3\n",
+			version: 1,
+			blocks:  [][]int{{3, 3}, {3}},
+		},
+		{
+			name:    "empty block columns are skipped",
+			csv:     header + "1,a,easy,4,First,a1,a2,\n1,a,easy,4,Second,b1,,b3\n",
+			version: 1,
+			blocks:  [][]int{{2, 2}},
+		},
+		{
+			name:    "rows with only the leading columns have no blocks",
+			csv:     header + "1,a,easy,4,First\n",
+			version: 1,
+			blocks:  [][]int{{0}},
+		},
+		{
+			name: "missing header",
+			csv:  "1,a,easy,4,First,a1,a2,a3\n",
+			err:  ErrPuzzleFileCSVHeader,
+		},
+		{
+			name: "header without block columns",
+			csv:  "version,puzzle,difficulty,max_attempts,description\n",
+			err:  ErrPuzzleFileCSVHeader,
+		},
+		{
+			name: "empty file",
+			csv:  "",
+			err:  ErrPuzzleFileCSVHeader,
+		},
+		{
+			name:       "short row",
+			csv:        header + "1,a,easy,4,First,a1,a2,a3\n1,a,easy\n",
+			errMessage: "Line 3 is missing columns.",
+		},
+		{
+			name:       "mixed versions",
+			csv:        header + "1,a,easy,4,First,a1,a2,a3\n2,a,easy,4,Second,b1,b2,b3\n",
+			errMessage: "Line 3 has a different version than the rows before it.",
+		},
+		{
+			name:       "invalid version",
+			csv:        header + "one,a,easy,4,First,a1,a2,a3\n",
+			errMessage: "Line 2 has an invalid version.",
+		},
+		{
+			name:       "invalid max attempts",
+			csv:        header + "1,a,easy,many,First,a1,a2,a3\n",
+			errMessage: "Line 2 has an invalid max attempts.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := ParsePuzzleFileCSV(strings.NewReader(tt.csv))
+			if tt.err != nil || tt.errMessage != "" {
+				if tt.err != nil && !errors.Is(err, tt.err) {
+					t.Fatalf("expected error %v, got %v", tt.err, err)
+				}
+				if tt.errMessage != "" && (err == nil || err.Error() != tt.errMessage) {
+					t.Fatalf("expected error %q, got %v", tt.errMessage, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to parse file: %v", err)
+			}
+
+			if file.Version != tt.version {
+				t.Errorf("expected version %d, got %d", tt.version, file.Version)
+			}
+
+			blocks := make([][]int, 0)
+			for _, puzzle := range file.Puzzles {
+				groups := make([]int, 0)
+				for _, group := range puzzle.Groups {
+					groups = append(groups, len(group.Blocks))
+				}
+
+				blocks = append(blocks, groups)
+			}
+			if !reflect.DeepEqual(blocks, tt.blocks) {
+				t.Errorf("expected blocks %v, got %v", tt.blocks, blocks)
+			}
+		})
+	}
+}
+
+func TestPuzzleFileCSVRoundTrip(t *testing.T) {
+	file := PuzzleFile{
+		Version: PuzzleFileVersion,
+		Puzzles: []PuzzleCreatePayload{
+			{
+				Difficulty:  "EASY",
+				MaxAttempts: 4,
+				Groups: []PuzzleCreatePayloadGroup{
+					{Description: "Fruits", Blocks: []PuzzleCreatePayloadBlock{{Value: "Apple"}, {Value: "Banana"}, {Value: "Cherry"}}},
+					{Description: "Colors, with a comma", Blocks: []PuzzleCreatePayloadBlock{{Value: "Red"}, {Value: "Green"}, {Value: "Blue"}}},
+				},
+			},
+			{
+				Difficulty:  "HARD",
+				MaxAttempts: 2,
+				Groups: []PuzzleCreatePayloadGroup{
+					{Description: "Pairs", Blocks: []PuzzleCreatePayloadBlock{{Value: "Salt"}, {Value: "Pepper"}}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := file.WriteCSV(&buf); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	parsed, err := ParsePuzzleFileCSV(&buf)
+	if err != nil {
+		t.Fatalf("failed to parse file: %v", err)
+	}
+	if err := parsed.Validate(); err != nil {
+		t.Fatalf("expected parsed file to be valid, got %v", err)
+	}
+	if !reflect.DeepEqual(parsed, file) {
+		t.Fatalf("expected %+v, got %+v", file, parsed)
+	}
+}
+
+func TestPuzzleFileValidateVersion(t *testing.T) {
+	file, err := ParsePuzzleFileCSV(strings.NewReader("version,puzzle,difficulty,max_attempts,description,block_1\n2,a,easy,4,First,a1\n"))
+	if err != nil {
+		t.Fatalf("failed to parse file: %v", err)
+	}
+
+	if err := file.Validate(); !errors.Is(err, ErrPuzzleFileVersion) {
+		t.Fatalf("expected error %v, got %v", ErrPuzzleFileVersion, err)
+	}
+}
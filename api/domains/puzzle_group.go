@@ -3,7 +3,6 @@ package domains
 import (
 	"github.com/RagOfJoes/puzzlely/internal"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/uptrace/bun"
 )
 
@@ -13,8 +12,10 @@ type PuzzleGroup struct {
 	bun.BaseModel
 
 	ID          string        `bun:"type:varchar(26),pk,notnull" json:"id"`
-	Description string        `bun:"type:varchar(512),notnull" json:"description"`
+	Description string        `bun:"type:varchar(2048),notnull" json:"description"`
 	Blocks      []PuzzleBlock `bun:"rel:has-many,join:id=puzzle_group_id" json:"blocks"`
+	// Hint defines an optional clue, written by the creator, that players can reveal at the cost of a point
+	Hint string `bun:"type:varchar(2048),nullzero" json:"hint,omitempty"`
 
 	PuzzleID string `bun:"type:varchar(26),notnull,unique:puzzle_groups_unique_idx" json:"-"`
 }
@@ -22,8 +23,9 @@ type PuzzleGroup struct {
 func (p PuzzleGroup) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
-		validation.Field(&p.Description, validation.Required, validation.Length(1, 512), is.PrintableASCII),
-		validation.Field(&p.Blocks, validation.Required, validation.Length(4, 4), validation.Each(validation.Required)),
+		validation.Field(&p.Description, validation.Required, validation.Length(1, 2048), internal.GraphemeLength(1, 512), internal.IsPrintable),
+		validation.Field(&p.Hint, validation.Length(0, 2048), internal.GraphemeLength(0, 512), internal.IsPrintable),
+		validation.Field(&p.Blocks, validation.Required, validation.Length(PuzzleMinGroupSize, PuzzleMaxGroupSize), validation.Each(validation.Required)),
 
 		validation.Field(&p.PuzzleID, validation.Required, validation.By(internal.IsULID)),
 	)
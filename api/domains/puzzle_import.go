@@ -0,0 +1,19 @@
+package domains
+
+// PuzzleImport defines the outcome of importing a puzzle file. Valid puzzles are created even if others in the same
+// file are not
+type PuzzleImport struct {
+	Created int `json:"created"`
+	Failed  int `json:"failed"`
+
+	Results []PuzzleImportResult `json:"results"`
+}
+
+// PuzzleImportResult defines the outcome of importing a single puzzle. Either `Puzzle` or `Error` is set
+type PuzzleImportResult struct {
+	// Index defines the position of the puzzle in the file
+	Index int `json:"index"`
+
+	Puzzle *Puzzle `json:"puzzle,omitempty"`
+	Error  string  `json:"error,omitempty"`
+}
@@ -0,0 +1,74 @@
+package domains
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	puzzleSearchCursorSeparator = ","
+)
+
+var _ Domain = (*PuzzleSearchOpts)(nil)
+
+// PuzzleSearchOpts defines the query, filters, and pagination for searching puzzles
+type PuzzleSearchOpts struct {
+	// Query is matched against the puzzle's group descriptions and block values
+	Query string `json:"-"`
+
+	// Difficulty only includes puzzles with the given difficulty
+	Difficulty string `json:"-"`
+	// Language only includes puzzles written in the given language
+	Language string `json:"-"`
+	// UserID only includes puzzles created by the given user
+	UserID string `json:"-"`
+	// Unplayed excludes puzzles that the currently authenticated user has already completed
+	Unplayed bool `json:"-"`
+
+	Cursor Cursor `json:"-"`
+	Limit  int    `json:"-"`
+}
+
+// NewPuzzleSearchCursor creates a cursor from the rank and creation date of a search result since results are
+// ordered by both
+func NewPuzzleSearchCursor(node PuzzleSummary) Cursor {
+	return NewCursor(fmt.Sprintf("%s%s%s", strconv.FormatFloat(node.Rank, 'f', 6, 64), puzzleSearchCursorSeparator, node.CreatedAt.Format("2006-01-02 15:04:05.000000")))
+}
+
+// DecodeCursor decodes the cursor into the rank and creation date that it was created from
+func (p PuzzleSearchOpts) DecodeCursor() (float64, string, error) {
+	decoded, err := p.Cursor.Decode()
+	if err != nil {
+		return 0, "", err
+	}
+
+	rank, createdAt, ok := strings.Cut(decoded, puzzleSearchCursorSeparator)
+	if !ok {
+		return 0, "", ErrCursorInvalid
+	}
+
+	parsed, err := strconv.ParseFloat(rank, 64)
+	if err != nil {
+		return 0, "", ErrCursorInvalid
+	}
+
+	return parsed, createdAt, nil
+}
+
+func (p PuzzleSearchOpts) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Query, validation.Required, validation.Length(1, 128)),
+
+		validation.Field(&p.Difficulty, validation.In("EASY", "MEDIUM", "HARD")),
+		validation.Field(&p.Language, validation.When(p.Language != "", validation.In(PuzzleLanguages...))),
+		validation.Field(&p.UserID, validation.When(p.UserID != "", validation.By(internal.IsULID))),
+		validation.Field(&p.Unplayed),
+
+		validation.Field(&p.Cursor),
+		validation.Field(&p.Limit, validation.Min(1), validation.Max(99)),
+	)
+}
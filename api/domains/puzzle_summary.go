@@ -16,16 +16,25 @@ type PuzzleSummary struct {
 	ID          string `bun:"type:varchar(26),pk,notnull" json:"id"`
 	Difficulty  string `bun:"type:varchar(12),default:'EASY',notnull" json:"difficulty"`
 	MaxAttempts int16  `bun:",notnull" json:"max_attempts"`
+	GroupCount  int    `bun:"type:smallint,notnull,default:4" json:"group_count"`
+	GroupSize   int    `bun:"type:smallint,notnull,default:4" json:"group_size"`
+	Language    string `bun:"type:varchar(8),notnull,default:'en'" json:"language"`
+
+	// Tags defines the topics that the creator attached to the puzzle
+	Tags []Tag `bun:"-" json:"tags,omitempty"`
 
 	// MeLikedAt defines when and if the currently authenticated user has liked this puzzle
 	MeLikedAt  bun.NullTime `bun:",scanonly" json:"me_liked_at"`
 	NumOfLikes int          `bun:",scanonly" json:"num_of_likes"`
 	// UserLikedAt defines when and if another user has liked this puzzle. This is primarily for viewing a user's liked puzzles
 	UserLikedAt bun.NullTime `bun:",scanonly" json:"user_liked_at"`
+	// Rank defines how closely the puzzle matched a search. This is only ever set for search results
+	Rank float64 `bun:",scanonly" json:"-"`
 
 	CreatedAt time.Time    `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt bun.NullTime `bun:",nullzero,default:NULL" json:"updated_at"`
-	DeletedAt bun.NullTime `bun:",soft_delete,nullzero,default:NULL" json:"-"`
+	// DeletedAt defines when the puzzle was deleted. This is only ever set for puzzles shown in a user's game history
+	DeletedAt bun.NullTime `bun:",soft_delete,nullzero,default:NULL" json:"deleted_at"`
 
 	UserID    string `bun:"type:varchar(26),notnull" json:"-"`
 	CreatedBy User   `bun:"rel:belongs-to,join:user_id=id" json:"created_by"`
@@ -36,6 +45,10 @@ func (p PuzzleSummary) Validate() error {
 		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
 		validation.Field(&p.Difficulty, validation.Required, validation.In("EASY", "MEDIUM", "HARD")),
 		validation.Field(&p.MaxAttempts, validation.Required, validation.Min(1), validation.Max(999)),
+		validation.Field(&p.GroupCount, validation.Required, validation.Min(PuzzleMinGroupCount), validation.Max(PuzzleMaxGroupCount)),
+		validation.Field(&p.GroupSize, validation.Required, validation.Min(PuzzleMinGroupSize), validation.Max(PuzzleMaxGroupSize)),
+		validation.Field(&p.Language, validation.Required, validation.In(PuzzleLanguages...)),
+		validation.Field(&p.Tags, validation.Length(0, PuzzleMaxTags)),
 
 		validation.Field(&p.MeLikedAt, validation.When(!p.MeLikedAt.IsZero(), validation.By(internal.IsAfter(p.CreatedAt)))),
 		validation.Field(&p.NumOfLikes, validation.Min(0)),
@@ -73,6 +73,37 @@ func BuildPuzzleSummaryConnectionForLiked(nodes []PuzzleSummary, limit int) (*Pu
 	return &connection, nil
 }
 
+func BuildPuzzleSummaryConnectionForSearch(nodes []PuzzleSummary, limit int) (*PuzzleSummaryConnection, error) {
+	edges := make([]PuzzleSummaryEdge, 0)
+	for _, node := range nodes {
+		edges = append(edges, PuzzleSummaryEdge{
+			Cursor: NewPuzzleSearchCursor(node),
+			Node:   node,
+		})
+	}
+
+	pageInfo := PageInfo{
+		HasNextPage:     len(edges) > limit,
+		HasPreviousPage: false,
+		NextCursor:      "",
+		PreviousCursor:  "",
+	}
+	if pageInfo.HasNextPage {
+		pageInfo.NextCursor = edges[len(edges)-1].Cursor
+		edges = edges[:len(edges)-1]
+	}
+
+	connection := PuzzleSummaryConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}
+	if err := connection.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
 func (p PuzzleSummaryConnection) Validate() error {
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.Edges, validation.NotNil),
@@ -0,0 +1,12 @@
+package domains
+
+import "github.com/uptrace/bun"
+
+// PuzzleTag defines the join table between puzzles and tags
+type PuzzleTag struct {
+	bun.BaseModel
+
+	PuzzleID string `bun:"type:varchar(26),pk,notnull"`
+	TagID    string `bun:"type:varchar(26),pk,notnull"`
+	Tag      *Tag   `bun:"rel:belongs-to,join:tag_id=id"`
+}
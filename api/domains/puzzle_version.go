@@ -0,0 +1,114 @@
+package domains
+
+import (
+	"slices"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/uptrace/bun"
+)
+
+var _ Domain = (*PuzzleVersion)(nil)
+
+// PuzzleVersion defines an immutable snapshot of a puzzle's content. A new version is made every time that the puzzle
+// is edited, and games are pinned to the version that they were started on
+type PuzzleVersion struct {
+	bun.BaseModel
+
+	PuzzleID string `bun:"type:varchar(26),pk,notnull" json:"puzzle_id"`
+	Version  int    `bun:",pk,notnull" json:"version"`
+
+	Difficulty  string               `bun:"type:varchar(12),notnull" json:"difficulty"`
+	MaxAttempts int16                `bun:",notnull" json:"max_attempts"`
+	Groups      []PuzzleVersionGroup `bun:"type:jsonb,notnull" json:"groups"`
+
+	// Changes defines what changed from the previous version. This is only ever set when listing versions
+	Changes []PuzzleVersionChange `bun:"-" json:"changes,omitempty"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// NewPuzzleVersion snapshots the current content of the given puzzle
+//
+// NOTE: `puzzle` must be loaded with its groups and blocks
+func NewPuzzleVersion(puzzle Puzzle) PuzzleVersion {
+	groups := make([]PuzzleVersionGroup, 0, len(puzzle.Groups))
+	for _, group := range puzzle.Groups {
+		blocks := make([]PuzzleVersionBlock, 0, len(group.Blocks))
+		for _, block := range group.Blocks {
+			blocks = append(blocks, PuzzleVersionBlock{
+				ID:    block.ID,
+				Value: block.Value,
+			})
+		}
+
+		groups = append(groups, PuzzleVersionGroup{
+			ID:          group.ID,
+			Description: group.Description,
+			Blocks:      blocks,
+		})
+	}
+
+	return PuzzleVersion{
+		PuzzleID: puzzle.ID,
+		Version:  puzzle.Version,
+
+		Difficulty:  puzzle.Difficulty,
+		MaxAttempts: puzzle.MaxAttempts,
+		Groups:      groups,
+
+		CreatedAt: time.Now(),
+	}
+}
+
+// Pin shows the given puzzle's content as it was at this version. Groups and blocks are matched by their id, and, any
+// that aren't in this version are left as they are
+//
+// NOTE: `puzzle` must be loaded with its groups and blocks
+func (p PuzzleVersion) Pin(puzzle *Puzzle) {
+	puzzle.Difficulty = p.Difficulty
+	puzzle.MaxAttempts = p.MaxAttempts
+
+	groups := make([]PuzzleGroup, 0, len(puzzle.Groups))
+	for _, group := range puzzle.Groups {
+		idx := slices.IndexFunc(p.Groups, func(versionGroup PuzzleVersionGroup) bool {
+			return versionGroup.ID == group.ID
+		})
+		if idx == -1 {
+			groups = append(groups, group)
+			continue
+		}
+
+		versionGroup := p.Groups[idx]
+		group.Description = versionGroup.Description
+
+		blocks := make([]PuzzleBlock, 0, len(group.Blocks))
+		for _, block := range group.Blocks {
+			for _, versionBlock := range versionGroup.Blocks {
+				if versionBlock.ID == block.ID {
+					block.Value = versionBlock.Value
+				}
+			}
+
+			blocks = append(blocks, block)
+		}
+		group.Blocks = blocks
+
+		groups = append(groups, group)
+	}
+	puzzle.Groups = groups
+}
+
+func (p PuzzleVersion) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.PuzzleID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&p.Version, validation.Required, validation.Min(1)),
+
+		validation.Field(&p.Difficulty, validation.Required, validation.In("EASY", "MEDIUM", "HARD")),
+		validation.Field(&p.MaxAttempts, validation.Required, validation.Min(1), validation.Max(999)),
+		validation.Field(&p.Groups, validation.Required, validation.Each(validation.Required)),
+
+		validation.Field(&p.CreatedAt, validation.Required),
+	)
+}
@@ -0,0 +1,21 @@
+package domains
+
+import (
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*PuzzleVersionBlock)(nil)
+
+// PuzzleVersionBlock defines a block as it was in a version of a puzzle
+type PuzzleVersionBlock struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+func (p PuzzleVersionBlock) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&p.Value, validation.Required, internal.GraphemeLength(1, 48)),
+	)
+}
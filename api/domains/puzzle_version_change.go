@@ -0,0 +1,103 @@
+package domains
+
+import (
+	"strconv"
+)
+
+// PuzzleVersionChange defines a single difference between two versions of a puzzle
+type PuzzleVersionChange struct {
+	// Field defines what changed. Either `difficulty`, `max_attempts`, `description`, or, `block`
+	Field string `json:"field"`
+	// GroupID defines the group that changed. This is only set for `description` and `block` changes
+	GroupID string `json:"group_id,omitempty"`
+	// BlockID defines the block that changed. This is only set for `block` changes
+	BlockID string `json:"block_id,omitempty"`
+
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DiffPuzzleVersions lists what changed going from one version of a puzzle to another. Groups and blocks are matched by
+// their id, so ones that only exist in one of the versions are reported with an empty `From` or `To`
+func DiffPuzzleVersions(from, to PuzzleVersion) []PuzzleVersionChange {
+	changes := make([]PuzzleVersionChange, 0)
+	if from.Difficulty != to.Difficulty {
+		changes = append(changes, PuzzleVersionChange{
+			Field: "difficulty",
+			From:  from.Difficulty,
+			To:    to.Difficulty,
+		})
+	}
+	if from.MaxAttempts != to.MaxAttempts {
+		changes = append(changes, PuzzleVersionChange{
+			Field: "max_attempts",
+			From:  strconv.Itoa(int(from.MaxAttempts)),
+			To:    strconv.Itoa(int(to.MaxAttempts)),
+		})
+	}
+
+	// Index the old version so that every group, and block, of the new version can be matched against it
+	groups := make(map[string]PuzzleVersionGroup, len(from.Groups))
+	blocks := make(map[string]string, 0)
+	for _, group := range from.Groups {
+		groups[group.ID] = group
+		for _, block := range group.Blocks {
+			blocks[block.ID] = block.Value
+		}
+	}
+
+	seenGroups := make(map[string]bool, len(to.Groups))
+	seenBlocks := make(map[string]bool, 0)
+	for _, group := range to.Groups {
+		seenGroups[group.ID] = true
+
+		if old := groups[group.ID]; old.Description != group.Description {
+			changes = append(changes, PuzzleVersionChange{
+				Field:   "description",
+				GroupID: group.ID,
+				From:    old.Description,
+				To:      group.Description,
+			})
+		}
+
+		for _, block := range group.Blocks {
+			seenBlocks[block.ID] = true
+
+			if old := blocks[block.ID]; old != block.Value {
+				changes = append(changes, PuzzleVersionChange{
+					Field:   "block",
+					GroupID: group.ID,
+					BlockID: block.ID,
+					From:    old,
+					To:      block.Value,
+				})
+			}
+		}
+	}
+
+	// Report anything that was removed in the new version
+	for _, group := range from.Groups {
+		if !seenGroups[group.ID] {
+			changes = append(changes, PuzzleVersionChange{
+				Field:   "description",
+				GroupID: group.ID,
+				From:    group.Description,
+			})
+		}
+
+		for _, block := range group.Blocks {
+			if seenBlocks[block.ID] {
+				continue
+			}
+
+			changes = append(changes, PuzzleVersionChange{
+				Field:   "block",
+				GroupID: group.ID,
+				BlockID: block.ID,
+				From:    block.Value,
+			})
+		}
+	}
+
+	return changes
+}
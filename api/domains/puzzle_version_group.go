@@ -0,0 +1,23 @@
+package domains
+
+import (
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*PuzzleVersionGroup)(nil)
+
+// PuzzleVersionGroup defines a group as it was in a version of a puzzle
+type PuzzleVersionGroup struct {
+	ID          string               `json:"id"`
+	Description string               `json:"description"`
+	Blocks      []PuzzleVersionBlock `json:"blocks"`
+}
+
+func (p PuzzleVersionGroup) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&p.Description, validation.Required, internal.GraphemeLength(1, 512)),
+		validation.Field(&p.Blocks, validation.Required, validation.Each(validation.Required)),
+	)
+}
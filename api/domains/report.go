@@ -0,0 +1,68 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/uptrace/bun"
+)
+
+// ReportReasons defines the reasons that a puzzle, or user, can be reported for
+var ReportReasons = []interface{}{"OFFENSIVE", "SPAM", "BROKEN", "HARASSMENT", "OTHER"}
+
+// ReportActions defines what a moderator can do with a report
+//
+// - DISMISS closes the report without doing anything else
+// - HIDE_PUZZLE removes the reported puzzle from every listing
+// - SUSPEND_USER stops the reported user, or the creator of the reported puzzle, from logging in
+var ReportActions = []interface{}{"DISMISS", "HIDE_PUZZLE", "SUSPEND_USER"}
+
+var _ Domain = (*Report)(nil)
+
+// Report defines a flag raised by a user against a puzzle or another user. Reports stay in the moderation queue until a
+// moderator resolves them
+type Report struct {
+	bun.BaseModel
+
+	ID      string `bun:"type:varchar(26),pk,notnull" json:"id"`
+	Reason  string `bun:"type:varchar(16),notnull" json:"reason"`
+	Details string `bun:"type:varchar(512),notnull" json:"details"`
+	// Action defines what the moderator did with the report. Empty while the report is still open
+	Action string `bun:"type:varchar(16),nullzero" json:"action"`
+
+	CreatedAt  time.Time    `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+	ResolvedAt bun.NullTime `bun:",nullzero,default:NULL" json:"resolved_at"`
+
+	// PuzzleID defines the reported puzzle. Empty when a user was reported directly
+	PuzzleID string `bun:"type:varchar(26),nullzero" json:"puzzle_id,omitempty"`
+	// ReportedUserID defines the reported user or the creator of the reported puzzle
+	ReportedUserID string `bun:"type:varchar(26),notnull" json:"-"`
+	ReportedUser   User   `bun:"rel:belongs-to,join:reported_user_id=id" json:"reported_user"`
+	// ResolvedBy defines the moderator that resolved the report
+	ResolvedBy string `bun:"type:varchar(26),nullzero" json:"-"`
+	// UserID defines the user that filed the report
+	UserID string `bun:"type:varchar(26),notnull" json:"-"`
+}
+
+// IsOpen checks whether the report is still waiting on a moderator
+func (r Report) IsOpen() bool {
+	return r.ResolvedAt.IsZero()
+}
+
+func (r Report) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&r.Reason, validation.Required, validation.In(ReportReasons...)),
+		validation.Field(&r.Details, validation.Length(0, 512)),
+		validation.Field(&r.Action, validation.When(!r.IsOpen(), validation.Required), validation.In(ReportActions...)),
+
+		validation.Field(&r.CreatedAt, validation.Required),
+		validation.Field(&r.ResolvedAt, validation.When(!r.ResolvedAt.IsZero(), validation.By(internal.IsAfter(r.CreatedAt)))),
+
+		validation.Field(&r.PuzzleID, validation.When(r.PuzzleID != "", validation.By(internal.IsULID))),
+		validation.Field(&r.ReportedUserID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&r.ResolvedBy, validation.When(!r.IsOpen(), validation.Required, validation.By(internal.IsULID))),
+		validation.Field(&r.UserID, validation.Required, validation.By(internal.IsULID), validation.NotIn(r.ReportedUserID)),
+	)
+}
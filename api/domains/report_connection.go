@@ -0,0 +1,51 @@
+package domains
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*ReportConnection)(nil)
+
+type ReportConnection struct {
+	Edges    []ReportEdge `json:"edges"`
+	PageInfo PageInfo     `json:"page_info"`
+}
+
+// BuildReportConnection builds a connection for reports ordered from oldest to newest
+func BuildReportConnection(nodes []Report, limit int) (*ReportConnection, error) {
+	edges := make([]ReportEdge, 0)
+	for _, node := range nodes {
+		edges = append(edges, ReportEdge{
+			Cursor: NewCursor(node.CreatedAt.Format("2006-01-02 15:04:05.000000")),
+			Node:   node,
+		})
+	}
+
+	pageInfo := PageInfo{
+		HasNextPage:     len(edges) > limit,
+		HasPreviousPage: false,
+		NextCursor:      "",
+		PreviousCursor:  "",
+	}
+	if pageInfo.HasNextPage {
+		pageInfo.NextCursor = edges[len(edges)-1].Cursor
+		edges = edges[:len(edges)-1]
+	}
+
+	connection := ReportConnection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}
+	if err := connection.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &connection, nil
+}
+
+func (r ReportConnection) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Edges, validation.NotNil),
+		validation.Field(&r.PageInfo, validation.Required),
+	)
+}
@@ -0,0 +1,17 @@
+package domains
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+var _ Domain = (*ReportCursorPaginationOpts)(nil)
+
+type ReportCursorPaginationOpts struct {
+	Cursor Cursor `json:"-"`
+	Limit  int    `json:"-"`
+}
+
+func (r ReportCursorPaginationOpts) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Cursor),
+		validation.Field(&r.Limit, validation.Min(1), validation.Max(99)),
+	)
+}
@@ -0,0 +1,18 @@
+package domains
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+var _ Domain = (*ReportEdge)(nil)
+
+// ReportEdge defines a paginated report list item
+type ReportEdge struct {
+	Cursor Cursor `json:"cursor"`
+	Node   Report `json:"node"`
+}
+
+func (r ReportEdge) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Cursor, validation.Required),
+		validation.Field(&r.Node, validation.Required),
+	)
+}
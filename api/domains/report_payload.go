@@ -0,0 +1,30 @@
+package domains
+
+import (
+	"net/http"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/go-chi/render"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*ReportPayload)(nil)
+var _ render.Binder = (*ReportPayload)(nil)
+
+// ReportPayload defines the payload for reporting a puzzle or a user
+type ReportPayload struct {
+	Reason string `json:"reason"`
+	// Details is optional free text that helps moderators understand the report
+	Details string `json:"details"`
+}
+
+func (r ReportPayload) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Reason, validation.Required, validation.In(ReportReasons...)),
+		validation.Field(&r.Details, validation.Length(0, 512), internal.IsSanitized),
+	)
+}
+
+func (r *ReportPayload) Bind(req *http.Request) error {
+	return nil
+}
@@ -0,0 +1,26 @@
+package domains
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*ReportResolvePayload)(nil)
+var _ render.Binder = (*ReportResolvePayload)(nil)
+
+// ReportResolvePayload defines the payload for a moderator resolving a report
+type ReportResolvePayload struct {
+	Action string `json:"action"`
+}
+
+func (r ReportResolvePayload) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Action, validation.Required, validation.In(ReportActions...)),
+	)
+}
+
+func (r *ReportResolvePayload) Bind(req *http.Request) error {
+	return nil
+}
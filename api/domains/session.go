@@ -75,9 +75,10 @@ func (s *Session) Authenticate(expire time.Time, user User) error {
 	return nil
 }
 
-// IsAuthenticated checks whether the session is propery authenticated and not expired
+// IsAuthenticated checks whether the session is propery authenticated, not expired, and doesn't belong to a suspended
+// user
 func (s *Session) IsAuthenticated() bool {
-	if s.State == Authenticated && !s.AuthenticatedAt.IsZero() && !s.IsExpired() && s.User != nil {
+	if s.State == Authenticated && !s.AuthenticatedAt.IsZero() && !s.IsExpired() && s.User != nil && !s.User.IsSuspended() {
 		return true
 	}
 
@@ -0,0 +1,121 @@
+package domains
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/oklog/ulid/v2"
+	"github.com/uptrace/bun"
+)
+
+// TagMaxLength defines the most graphemes that a tag's name can have
+const TagMaxLength = 32
+
+// Slugs are made up of letters and numbers, in any script, separated by single dashes
+var tagSlugRegex = regexp.MustCompile(`^[\p{L}\p{M}\p{N}]+(-[\p{L}\p{M}\p{N}]+)*$`)
+
+// Rules for the tag names that creators write. Names must have at least one letter or number so that they have a slug
+var tagNameRules = []validation.Rule{
+	validation.Required,
+	internal.GraphemeLength(1, TagMaxLength),
+	internal.IsPrintable,
+	internal.IsSanitized,
+	internal.IsClean,
+	validation.By(func(value interface{}) error {
+		if name, _ := value.(string); name != "" && TagSlug(name) == "" {
+			return errors.New("must contain a letter or number")
+		}
+
+		return nil
+	}),
+}
+
+var _ Domain = (*Tag)(nil)
+
+// Tag defines a topic, like "music" or "geography", that creators can attach to their puzzles. Tags are shared
+// between puzzles and are identified by their slug so that "Hip Hop" and "hip-hop" are the same tag
+type Tag struct {
+	bun.BaseModel
+
+	ID   string `bun:"type:varchar(26),pk,notnull" json:"id"`
+	Slug string `bun:"type:varchar(128),notnull,unique" json:"slug"`
+	// Name defines how the tag was written by the first creator to use it
+	Name string `bun:"type:varchar(128),notnull" json:"name"`
+	// NumOfPuzzles defines the number of listed puzzles that use the tag. Deleted and hidden puzzles aren't counted
+	NumOfPuzzles int `bun:",notnull,default:0" json:"num_of_puzzles"`
+
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// NewTag creates a tag from the name that a creator wrote
+func NewTag(name string) Tag {
+	name = strings.Join(strings.Fields(internal.Normalize(name)), " ")
+
+	return Tag{
+		ID:   ulid.Make().String(),
+		Slug: TagSlug(name),
+		Name: name,
+	}
+}
+
+// TagSlug creates the slug for the given tag name. The name is lowercased and every run of characters that aren't
+// letters or numbers is replaced by a single dash. Returns an empty string if the name has no letters or numbers
+func TagSlug(name string) string {
+	name = strings.ToLower(internal.Normalize(name))
+
+	var sb strings.Builder
+	dash := false
+	for _, r := range name {
+		if unicode.In(r, unicode.L, unicode.M, unicode.N) {
+			if dash && sb.Len() > 0 {
+				sb.WriteRune('-')
+			}
+			dash = false
+
+			sb.WriteRune(r)
+			continue
+		}
+
+		dash = true
+	}
+
+	return sb.String()
+}
+
+func (t Tag) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.ID, validation.Required, validation.By(internal.IsULID)),
+		validation.Field(&t.Slug, validation.Required, internal.GraphemeLength(1, TagMaxLength), validation.Match(tagSlugRegex)),
+		validation.Field(&t.Name, validation.Required, internal.GraphemeLength(1, TagMaxLength), internal.IsPrintable),
+		validation.Field(&t.NumOfPuzzles, validation.Min(0)),
+	)
+}
+
+// Normalizes the given tag names and removes the names whose slug was already seen. Names without a slug are kept so
+// that they can be reported by validation
+func normalizeTags(names []string) []string {
+	if names == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = internal.Normalize(name)
+
+		slug := TagSlug(name)
+		if slug != "" && seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		normalized = append(normalized, name)
+	}
+
+	return normalized
+}
@@ -0,0 +1,24 @@
+package domains
+
+import (
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*TagSearchOpts)(nil)
+
+// TagSearchOpts defines the query and limit for autocompleting tags
+type TagSearchOpts struct {
+	// Query is matched against the start of a tag's slug. An empty query matches every tag
+	Query string `json:"-"`
+
+	Limit int `json:"-"`
+}
+
+func (t TagSearchOpts) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Query, internal.GraphemeLength(0, TagMaxLength)),
+
+		validation.Field(&t.Limit, validation.Min(1), validation.Max(99)),
+	)
+}
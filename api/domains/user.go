@@ -2,6 +2,7 @@ package domains
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/RagOfJoes/puzzlely/internal"
@@ -18,10 +19,23 @@ type User struct {
 	ID       string `bun:"type:varchar(26),pk,notnull" json:"id"`
 	State    string `bun:"type:varchar(8),default:'PENDING',notnull" json:"state"`
 	Username string `bun:"type:varchar(64),unique,notnull" json:"username"`
+	// Timezone defines the IANA timezone that decides when the user's days start and end
+	Timezone string `bun:"type:varchar(64),default:'UTC',notnull" json:"timezone"`
+	// Role defines what the user is allowed to do. See `UserRoles`
+	Role string `bun:"type:varchar(16),default:'CREATOR',notnull" json:"role"`
+
+	// Streak defines the user's play and win streaks. This is only ever set when viewing a single user
+	Streak *UserStreak `bun:"-" json:"streak,omitempty"`
+	// Permissions defines everything that the user's role allows. This is only ever set for the authenticated user
+	Permissions []Permission `bun:"-" json:"permissions,omitempty"`
 
 	CreatedAt time.Time    `bun:",nullzero,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt bun.NullTime `bun:",nullzero,default:NULL" json:"updated_at"`
+	// DeletedAt defines when the user deleted their account. Deleted users are filtered out by bun unless the query
+	// explicitly includes them, e.g. to show them as the anonymized creator of puzzles and games
 	DeletedAt bun.NullTime `bun:",soft_delete,nullzero,default:NULL" json:"-"`
+	// SuspendedAt defines when a moderator suspended the user. Sessions of suspended users are never authenticated
+	SuspendedAt bun.NullTime `bun:",nullzero,default:NULL" json:"-"`
 }
 
 func NewUser() User {
@@ -30,12 +44,47 @@ func NewUser() User {
 	return User{
 		ID:       id,
 		State:    "PENDING",
+		Role:     "CREATOR",
 		Username: fmt.Sprintf("temp-%s", id),
+		Timezone: "UTC",
 
 		CreatedAt: time.Now(),
 	}
 }
 
+// Anonymize removes any identifiable information from the user and marks them as deleted
+func (u *User) Anonymize() {
+	now := time.Now()
+
+	u.Username = fmt.Sprintf("deleted-%s", u.ID)
+	u.UpdatedAt = bun.NullTime{
+		Time: now,
+	}
+	u.DeletedAt = bun.NullTime{
+		Time: now,
+	}
+}
+
+// Location returns the location of `Timezone`. Falls back to UTC if `Timezone` is empty or invalid
+func (u User) Location() *time.Location {
+	location, err := time.LoadLocation(u.Timezone)
+	if err != nil || u.Timezone == "" {
+		return time.UTC
+	}
+
+	return location
+}
+
+// Can checks if the user's role grants the given permission. Suspended users can't do anything
+func (u *User) Can(permission Permission) bool {
+	return !u.IsSuspended() && slices.Contains(RolePermissions(u.Role), permission)
+}
+
+// IsSuspended checks if the user has been suspended by a moderator
+func (u *User) IsSuspended() bool {
+	return !u.SuspendedAt.IsZero()
+}
+
 // IsComplete checks if the user has completed all the steps to setup their profile
 func (u *User) IsComplete() bool {
 	return u.State == "COMPLETE" && !u.UpdatedAt.IsZero()
@@ -45,9 +94,13 @@ func (u User) Validate() error {
 	return validation.ValidateStruct(&u,
 		validation.Field(&u.ID, validation.Required, validation.By(internal.IsULID)),
 		validation.Field(&u.State, validation.Required, validation.In("PENDING", "COMPLETE")),
+		validation.Field(&u.Role, validation.Required, validation.In(UserRoles...)),
 		validation.Field(&u.Username, validation.Required, validation.Length(4, 64)),
+		validation.Field(&u.Timezone, validation.Required, internal.IsTimezone),
+		validation.Field(&u.Streak, validation.When(u.Streak != nil, validation.Required)),
 		validation.Field(&u.CreatedAt, validation.Required),
 		validation.Field(&u.UpdatedAt, validation.When(!u.UpdatedAt.IsZero(), validation.By(internal.IsAfter(u.CreatedAt)))),
 		validation.Field(&u.DeletedAt, validation.When(!u.DeletedAt.IsZero(), validation.By(internal.IsAfter(u.CreatedAt)))),
+		validation.Field(&u.SuspendedAt, validation.When(!u.SuspendedAt.IsZero(), validation.By(internal.IsAfter(u.CreatedAt)))),
 	)
 }
@@ -0,0 +1,26 @@
+package domains
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*UserRolePayload)(nil)
+var _ render.Binder = (*UserRolePayload)(nil)
+
+// UserRolePayload defines the payload for an admin changing the role of a user
+type UserRolePayload struct {
+	Role string `json:"role"`
+}
+
+func (u UserRolePayload) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Role, validation.Required, validation.In(UserRoles...)),
+	)
+}
+
+func (u *UserRolePayload) Bind(r *http.Request) error {
+	return nil
+}
@@ -0,0 +1,117 @@
+package domains
+
+import (
+	"slices"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var _ Domain = (*UserStats)(nil)
+var _ Domain = (*UserStatsDifficulty)(nil)
+var _ Domain = (*UserStatsGroupsFound)(nil)
+
+// UserStatsDifficulty defines how often a user wins the puzzles of a difficulty
+type UserStatsDifficulty struct {
+	Difficulty string `json:"difficulty"`
+	// Completed defines the number of completed games
+	Completed int `json:"completed"`
+	// Won defines the number of completed games where every group was found
+	Won     int     `json:"won"`
+	WinRate float64 `json:"win_rate"`
+}
+
+func NewUserStatsDifficulty(difficulty string, completed, won int) UserStatsDifficulty {
+	stats := UserStatsDifficulty{
+		Difficulty: difficulty,
+		Completed:  completed,
+		Won:        won,
+	}
+	if completed > 0 {
+		stats.WinRate = float64(won) / float64(completed)
+	}
+
+	return stats
+}
+
+func (u UserStatsDifficulty) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Difficulty, validation.Required, validation.In("EASY", "MEDIUM", "HARD")),
+		validation.Field(&u.Completed, validation.Min(0)),
+		validation.Field(&u.Won, validation.Min(0), validation.Max(u.Completed)),
+		validation.Field(&u.WinRate, validation.Min(0.0), validation.Max(1.0)),
+	)
+}
+
+// UserStatsGroupsFound defines the number of completed games where exactly `Groups` groups were found
+type UserStatsGroupsFound struct {
+	Groups int `json:"groups"`
+	Count  int `json:"count"`
+}
+
+// NewUserStatsGroupsFound builds the distribution from the number of completed games keyed by the number of groups
+// found. Every number of groups, from none to all of them, is included even when there are no games for it
+func NewUserStatsGroupsFound(counts map[int]int) []UserStatsGroupsFound {
+	groups := PuzzleDefaultGroupCount
+	for found := range counts {
+		groups = max(groups, found)
+	}
+
+	distribution := make([]UserStatsGroupsFound, 0)
+	for found := 0; found <= groups; found++ {
+		distribution = append(distribution, UserStatsGroupsFound{
+			Groups: found,
+			Count:  counts[found],
+		})
+	}
+
+	return distribution
+}
+
+func (u UserStatsGroupsFound) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Groups, validation.Min(0)),
+		validation.Field(&u.Count, validation.Min(0)),
+	)
+}
+
+// UserStats defines a user's aggregated games and puzzles
+type UserStats struct {
+	GamesPlayed    int `json:"games_played"`
+	GamesCompleted int `json:"games_completed"`
+	// PerfectSolves defines the number of completed games where every group was found without a wrong attempt
+	PerfectSolves int `json:"perfect_solves"`
+	// AverageAttempts defines the average number of attempts of completed games
+	AverageAttempts float64 `json:"average_attempts"`
+
+	// Difficulties defines the win rate for each difficulty that the user has completed a game in
+	Difficulties []UserStatsDifficulty `json:"difficulties"`
+	// GroupsFound defines the distribution of how many groups were found in completed games
+	GroupsFound []UserStatsGroupsFound `json:"groups_found"`
+
+	PuzzlesCreated int `json:"puzzles_created"`
+	// LikesReceived defines the number of active likes on the puzzles that the user has created
+	LikesReceived int `json:"likes_received"`
+}
+
+// SortDifficulties sorts `Difficulties` from easiest to hardest
+func (u *UserStats) SortDifficulties() {
+	order := []string{"EASY", "MEDIUM", "HARD"}
+	slices.SortFunc(u.Difficulties, func(a, b UserStatsDifficulty) int {
+		return slices.Index(order, a.Difficulty) - slices.Index(order, b.Difficulty)
+	})
+}
+
+func (u UserStats) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.GamesPlayed, validation.Min(0)),
+		validation.Field(&u.GamesCompleted, validation.Min(0), validation.Max(u.GamesPlayed)),
+		validation.Field(&u.PerfectSolves, validation.Min(0), validation.Max(u.GamesCompleted)),
+		validation.Field(&u.AverageAttempts, validation.Min(0.0)),
+
+		validation.Field(&u.Difficulties, validation.NotNil),
+		validation.Field(&u.GroupsFound, validation.NotNil),
+
+		validation.Field(&u.PuzzlesCreated, validation.Min(0)),
+		validation.Field(&u.LikesReceived, validation.Min(0)),
+	)
+}
@@ -0,0 +1,106 @@
+package domains
+
+import (
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/uptrace/bun"
+)
+
+var _ Domain = (*UserStreak)(nil)
+
+// UserStreak defines a user's consecutive days of completing, and winning, at least one game. Days are based on the
+// user's timezone
+type UserStreak struct {
+	bun.BaseModel
+
+	UserID string `bun:"type:varchar(26),pk,notnull" json:"-"`
+
+	CurrentPlay int `bun:",notnull" json:"current_play"`
+	LongestPlay int `bun:",notnull" json:"longest_play"`
+	CurrentWin  int `bun:",notnull" json:"current_win"`
+	LongestWin  int `bun:",notnull" json:"longest_win"`
+
+	LastPlayedOn bun.NullTime `bun:"type:date,nullzero,default:NULL" json:"last_played_on"`
+	LastWonOn    bun.NullTime `bun:"type:date,nullzero,default:NULL" json:"last_won_on"`
+
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+func NewUserStreak(userID string) UserStreak {
+	return UserStreak{
+		UserID: userID,
+
+		UpdatedAt: time.Now(),
+	}
+}
+
+// StreakDay returns the calendar day of the given time in the given location. The day is returned as midnight UTC so
+// that days can be compared regardless of the timezone they came from
+func StreakDay(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.In(location).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Expire resets the current streaks that were broken by neither playing, or winning, yesterday nor today
+func (u *UserStreak) Expire(today time.Time) {
+	if !isStreakAlive(u.LastPlayedOn, today) {
+		u.CurrentPlay = 0
+	}
+	if !isStreakAlive(u.LastWonOn, today) {
+		u.CurrentWin = 0
+	}
+}
+
+// Record updates the streaks with a game that was completed on the given day
+func (u *UserStreak) Record(day time.Time, won bool) {
+	u.CurrentPlay, u.LastPlayedOn = nextStreak(u.CurrentPlay, u.LastPlayedOn, day)
+	u.LongestPlay = max(u.LongestPlay, u.CurrentPlay)
+
+	if won {
+		u.CurrentWin, u.LastWonOn = nextStreak(u.CurrentWin, u.LastWonOn, day)
+		u.LongestWin = max(u.LongestWin, u.CurrentWin)
+	}
+
+	u.UpdatedAt = time.Now()
+}
+
+func (u UserStreak) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.UserID, validation.Required, validation.By(internal.IsULID)),
+
+		validation.Field(&u.CurrentPlay, validation.Min(0), validation.Max(u.LongestPlay)),
+		validation.Field(&u.LongestPlay, validation.Min(0)),
+		validation.Field(&u.CurrentWin, validation.Min(0), validation.Max(u.LongestWin)),
+		validation.Field(&u.LongestWin, validation.Min(0)),
+
+		validation.Field(&u.UpdatedAt, validation.Required),
+	)
+}
+
+// Checks whether a streak that was last extended on the given day is still ongoing
+func isStreakAlive(last bun.NullTime, today time.Time) bool {
+	if last.IsZero() {
+		return false
+	}
+
+	return !StreakDay(last.Time, time.UTC).Before(today.AddDate(0, 0, -1))
+}
+
+// Returns the streak after extending it with the given day. Days that are on, or before, the last day don't change the
+// streak
+func nextStreak(current int, last bun.NullTime, day time.Time) (int, bun.NullTime) {
+	if !last.IsZero() {
+		lastDay := StreakDay(last.Time, time.UTC)
+		if !day.After(lastDay) {
+			return current, last
+		}
+		if day.Equal(lastDay.AddDate(0, 0, 1)) {
+			return current + 1, bun.NullTime{Time: day}
+		}
+	}
+
+	return 1, bun.NullTime{Time: day}
+}
@@ -0,0 +1,187 @@
+package domains
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestStreakDay(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     time.Time
+		timezone string
+		day      time.Time
+	}{
+		{
+			name:     "same day in UTC",
+			time:     time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC),
+			timezone: "UTC",
+			day:      time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "previous day behind UTC",
+			time:     time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC),
+			timezone: "America/New_York",
+			day:      time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "next day ahead of UTC",
+			time:     time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC),
+			timezone: "Asia/Tokyo",
+			day:      time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "midnight is the start of the day",
+			time:     time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC),
+			timezone: "Asia/Tokyo",
+			day:      time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "daylight saving time",
+			time:     time.Date(2026, 7, 1, 3, 30, 0, 0, time.UTC),
+			timezone: "America/New_York",
+			day:      time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "invalid timezone falls back to UTC",
+			time:     time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC),
+			timezone: "Not/A_Timezone",
+			day:      time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := User{Timezone: tt.timezone}
+
+			day := StreakDay(tt.time, user.Location())
+			if !day.Equal(tt.day) {
+				t.Fatalf("expected %s, got %s", tt.day.Format(time.DateOnly), day.Format(time.DateOnly))
+			}
+		})
+	}
+}
+
+func TestUserStreakRecord(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	type record struct {
+		day time.Time
+		won bool
+	}
+
+	tests := []struct {
+		name    string
+		records []record
+
+		currentPlay int
+		longestPlay int
+		currentWin  int
+		longestWin  int
+	}{
+		{
+			name:        "first game",
+			records:     []record{{day(1), true}},
+			currentPlay: 1, longestPlay: 1, currentWin: 1, longestWin: 1,
+		},
+		{
+			name:        "consecutive days",
+			records:     []record{{day(1), true}, {day(2), true}, {day(3), true}},
+			currentPlay: 3, longestPlay: 3, currentWin: 3, longestWin: 3,
+		},
+		{
+			name:        "same day is only counted once",
+			records:     []record{{day(1), true}, {day(1), true}, {day(2), false}, {day(2), true}},
+			currentPlay: 2, longestPlay: 2, currentWin: 2, longestWin: 2,
+		},
+		{
+			name:        "losses only extend the play streak",
+			records:     []record{{day(1), true}, {day(2), false}, {day(3), true}},
+			currentPlay: 3, longestPlay: 3, currentWin: 1, longestWin: 1,
+		},
+		{
+			name:        "skipped day restarts the streaks",
+			records:     []record{{day(1), true}, {day(2), true}, {day(4), true}},
+			currentPlay: 1, longestPlay: 2, currentWin: 1, longestWin: 2,
+		},
+		{
+			name:        "earlier days don't change the streaks",
+			records:     []record{{day(2), true}, {day(3), true}, {day(1), true}},
+			currentPlay: 2, longestPlay: 2, currentWin: 2, longestWin: 2,
+		},
+		{
+			name:        "across months",
+			records:     []record{{time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), true}, {day(1), true}},
+			currentPlay: 2, longestPlay: 2, currentWin: 2, longestWin: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak := NewUserStreak("")
+			for _, r := range tt.records {
+				streak.Record(r.day, r.won)
+			}
+
+			if streak.CurrentPlay != tt.currentPlay || streak.LongestPlay != tt.longestPlay {
+				t.Errorf("expected play streak of %d (longest %d), got %d (longest %d)", tt.currentPlay, tt.longestPlay, streak.CurrentPlay, streak.LongestPlay)
+			}
+			if streak.CurrentWin != tt.currentWin || streak.LongestWin != tt.longestWin {
+				t.Errorf("expected win streak of %d (longest %d), got %d (longest %d)", tt.currentWin, tt.longestWin, streak.CurrentWin, streak.LongestWin)
+			}
+		})
+	}
+}
+
+// Games completed late at night for the player are recorded on their own calendar day rather than on the UTC one
+func TestUserStreakRecordTimezone(t *testing.T) {
+	user := User{Timezone: "America/Los_Angeles"}
+
+	streak := NewUserStreak("")
+	// 2026-03-01 22:00 and 2026-03-02 21:00 in Los Angeles, which are both the next day in UTC
+	streak.Record(StreakDay(time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC), user.Location()), true)
+	streak.Record(StreakDay(time.Date(2026, 3, 3, 5, 0, 0, 0, time.UTC), user.Location()), true)
+
+	if streak.CurrentPlay != 2 {
+		t.Fatalf("expected play streak of 2, got %d", streak.CurrentPlay)
+	}
+	if expected := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC); !streak.LastPlayedOn.Equal(expected) {
+		t.Fatalf("expected last played on %s, got %s", expected.Format(time.DateOnly), streak.LastPlayedOn.Format(time.DateOnly))
+	}
+}
+
+func TestUserStreakExpire(t *testing.T) {
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		lastPlay time.Time
+		lastWin  time.Time
+
+		currentPlay int
+		currentWin  int
+	}{
+		{name: "played today", lastPlay: today, lastWin: today, currentPlay: 3, currentWin: 3},
+		{name: "played yesterday", lastPlay: today.AddDate(0, 0, -1), lastWin: today.AddDate(0, 0, -1), currentPlay: 3, currentWin: 3},
+		{name: "won two days ago", lastPlay: today, lastWin: today.AddDate(0, 0, -2), currentPlay: 3, currentWin: 0},
+		{name: "never played", currentPlay: 0, currentWin: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak := NewUserStreak("")
+			streak.CurrentPlay, streak.LongestPlay = 3, 3
+			streak.CurrentWin, streak.LongestWin = 3, 3
+			streak.LastPlayedOn.Time = tt.lastPlay
+			streak.LastWonOn.Time = tt.lastWin
+
+			streak.Expire(today)
+			if streak.CurrentPlay != tt.currentPlay || streak.CurrentWin != tt.currentWin {
+				t.Errorf("expected streaks of %d and %d, got %d and %d", tt.currentPlay, tt.currentWin, streak.CurrentPlay, streak.CurrentWin)
+			}
+			if streak.LongestPlay != 3 || streak.LongestWin != 3 {
+				t.Errorf("expected longest streaks to be kept, got %d and %d", streak.LongestPlay, streak.LongestWin)
+			}
+		})
+	}
+}
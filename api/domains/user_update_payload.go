@@ -10,6 +10,8 @@ import (
 
 type UserUpdatePayload struct {
 	Username string `json:"username"`
+	// Timezone is optional. When empty the user's current timezone is kept
+	Timezone string `json:"timezone"`
 }
 
 func (u *UserUpdatePayload) Bind(r *http.Request) error {
@@ -25,5 +27,6 @@ func (u UserUpdatePayload) Validate() error {
 
 	return validation.ValidateStruct(&u,
 		validation.Field(&u.Username, validation.Required, validation.Length(4, 64), internal.IsUsername, internal.IsSanitized, internal.IsClean),
+		validation.Field(&u.Timezone, internal.IsTimezone),
 	)
 }
@@ -61,7 +61,7 @@ func Auth(dependencies AuthDependencies, router *chi.Mux) {
 		session: dependencies.Session,
 	}
 
-	router.Post("/auth/{provider}", a.authenticate)
+	router.With(RateLimit(a.config.Server.RateLimits.Auth, a.session)).Post("/auth/{provider}", a.authenticate)
 	router.Delete("/logout", a.logout)
 }
 
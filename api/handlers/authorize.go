@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrForbidden = errors.New("You don't have permission to access this resource.")
+)
+
+// Authorize requires the caller to be authenticated and for their role to grant the permission. The session is added
+// to the request's context so handlers behind it can read it with `domains.SessionFromContext`
+func Authorize(session session, permission domains.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			span := trace.SpanFromContext(r.Context())
+
+			found, err := session.Get(w, r, true)
+			if err != nil {
+				span.SetStatus(codes.Error, "")
+
+				render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+				return
+			}
+			if !found.User.Can(permission) {
+				span.SetStatus(codes.Error, "")
+				span.RecordError(ErrForbidden)
+
+				render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeForbidden, "%v", ErrForbidden))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/config"
+	"github.com/RagOfJoes/puzzlely/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/oklog/ulid/v2"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrChallengeCursorPaginationOpts = errors.New("Invalid cursor pagination options provided.")
+	ErrChallengeInvalidPayload       = errors.New("Invalid challenge provided.")
+)
+
+type challenge struct {
+	config config.Configuration
+
+	service services.Challenge
+
+	session session
+}
+
+type ChallengeDependencies struct {
+	Config config.Configuration
+
+	Service services.Challenge
+
+	Session session
+}
+
+func Challenge(dependencies ChallengeDependencies, router *chi.Mux) {
+	c := &challenge{
+		config: dependencies.Config,
+
+		service: dependencies.Service,
+
+		session: dependencies.Session,
+	}
+
+	limit := RateLimit(c.config.Server.RateLimits.Games, c.session)
+
+	router.Route("/challenges", func(r chi.Router) {
+		r.Get("/", c.list)
+		r.Get("/{id}", c.get)
+
+		r.With(limit).Post("/", c.create)
+
+		r.With(limit).Put("/{id}/accept", c.accept)
+	})
+}
+
+func (c *challenge) accept(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	if _, err := c.session.Get(w, r, true); err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	challenge, err := c.service.Accept(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", challenge))
+}
+
+func (c *challenge) create(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.ChallengePayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrChallengeInvalidPayload)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrChallengeInvalidPayload))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrChallengeInvalidPayload)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	if _, err := c.session.Get(w, r, true); err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	challenge, err := c.service.New(r.Context(), payload)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Created("", challenge))
+}
+
+func (c *challenge) get(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	c.session.Get(w, r, false)
+
+	challenge, err := c.service.Find(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", challenge))
+}
+
+func (c *challenge) list(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	cursor, err := domains.CursorFromString(r.URL.Query().Get("cursor"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrChallengeCursorPaginationOpts))
+		return
+	}
+
+	if _, err := c.session.Get(w, r, true); err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	opts := domains.ChallengeCursorPaginationOpts{
+		Cursor: cursor,
+		Limit:  20,
+	}
+	connection, err := c.service.FindForUser(r.Context(), opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", connection))
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrDailyPuzzleInvalidPayload = errors.New("Invalid daily puzzle provided.")
+)
+
+type dailyPuzzle struct {
+	service services.DailyPuzzle
+
+	session session
+}
+
+type DailyPuzzleDependencies struct {
+	Service services.DailyPuzzle
+
+	Session session
+}
+
+func DailyPuzzle(dependencies DailyPuzzleDependencies, router *chi.Mux) {
+	d := &dailyPuzzle{
+		service: dependencies.Service,
+
+		session: dependencies.Session,
+	}
+
+	router.Route("/puzzles/daily", func(r chi.Router) {
+		r.Get("/", d.daily)
+		r.Get("/past", d.past)
+	})
+
+	router.Route("/admin/daily", func(r chi.Router) {
+		r.Use(Authorize(d.session, domains.PermissionManageDaily))
+
+		r.Get("/", d.upcoming)
+
+		r.Post("/", d.schedule)
+
+		r.Delete("/{date}", d.unschedule)
+	})
+}
+
+func (d *dailyPuzzle) daily(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	d.session.Get(w, r, false)
+
+	dailyPuzzle, err := d.service.Find(r.Context(), r.URL.Query().Get("date"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", dailyPuzzle))
+}
+
+func (d *dailyPuzzle) past(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	cursor, err := domains.CursorFromString(r.URL.Query().Get("cursor"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	d.session.Get(w, r, false)
+
+	opts := domains.PuzzleCursorPaginationOpts{
+		Cursor: cursor,
+		Limit:  12,
+	}
+	connection, err := d.service.FindPast(r.Context(), opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", connection))
+}
+
+func (d *dailyPuzzle) schedule(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.DailyPuzzlePayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrDailyPuzzleInvalidPayload)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzleInvalidPayload))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrDailyPuzzleInvalidPayload)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzleInvalidPayload))
+		return
+	}
+
+	dailyPuzzle, err := d.service.Schedule(r.Context(), payload)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Created("", dailyPuzzle))
+}
+
+func (d *dailyPuzzle) unschedule(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	if err := d.service.Unschedule(r.Context(), chi.URLParam(r, "date")); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", true))
+}
+
+func (d *dailyPuzzle) upcoming(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	dailyPuzzles, err := d.service.FindUpcoming(r.Context())
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", dailyPuzzles))
+}
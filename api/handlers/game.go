@@ -6,6 +6,7 @@ import (
 
 	"github.com/RagOfJoes/puzzlely/domains"
 	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/config"
 	"github.com/RagOfJoes/puzzlely/internal/telemetry"
 	"github.com/RagOfJoes/puzzlely/services"
 	"github.com/go-chi/chi/v5"
@@ -17,11 +18,14 @@ import (
 
 // Errors
 var (
-	ErrGameAlreadyExists  = errors.New("Game already exists.")
-	ErrGameInvalidPayload = errors.New("Invalid game provided.")
+	ErrGameAlreadyExists       = errors.New("Game already exists.")
+	ErrGameInvalidGuessPayload = errors.New("Invalid guess provided.")
+	ErrGameInvalidHintPayload  = errors.New("Invalid hint provided.")
+	ErrGameInvalidPayload      = errors.New("Invalid game provided.")
 )
 
 type game struct {
+	config config.Configuration
 	tracer trace.Tracer
 
 	puzzle  services.Puzzle
@@ -32,6 +36,8 @@ type game struct {
 }
 
 type GameDependencies struct {
+	Config config.Configuration
+
 	Puzzle  services.Puzzle
 	Service services.Game
 	User    services.User
@@ -41,6 +47,7 @@ type GameDependencies struct {
 
 func Game(dependencies GameDependencies, router *chi.Mux) {
 	g := &game{
+		config: dependencies.Config,
 		tracer: telemetry.Tracer("handlers.game"),
 
 		puzzle:  dependencies.Puzzle,
@@ -50,11 +57,17 @@ func Game(dependencies GameDependencies, router *chi.Mux) {
 		session: dependencies.Session,
 	}
 
+	limit := RateLimit(g.config.Server.RateLimits.Games, g.session)
+
 	router.Route("/games", func(r chi.Router) {
 		r.Get("/{puzzle_id}", g.get)
+		r.Get("/{puzzle_id}/share", g.share)
 		r.Get("/history/{user_id}", g.history)
 
-		r.Put("/{puzzle_id}", g.save)
+		r.With(limit).Post("/{puzzle_id}/guess", g.guess)
+		r.With(limit).Post("/{puzzle_id}/hints", g.hint)
+
+		r.With(limit).Put("/{puzzle_id}", g.save)
 	})
 }
 
@@ -86,7 +99,159 @@ func (g *game) get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	render.Render(w, r, Ok("", game))
+	render.Render(w, r, Ok("", domains.NewPlayerGame(*game)))
+}
+
+func (g *game) guess(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.GameGuessPayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrGameInvalidGuessPayload)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrGameInvalidGuessPayload))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrGameInvalidGuessPayload)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrGameInvalidGuessPayload))
+		return
+	}
+
+	id, err := ulid.Parse(chi.URLParam(r, "puzzle_id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	session, err := g.session.Get(w, r, true)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	puzzle, err := g.puzzle.Find(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	// Continue the user's saved game or start a new one if there isn't one yet
+	game, err := g.service.Start(r.Context(), *puzzle, *session.User)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+	status := http.StatusOK
+	if game.Revision == 0 {
+		status = http.StatusCreated
+	}
+
+	saved, err := g.service.Guess(r.Context(), *game, payload)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	if status == http.StatusCreated {
+		render.Render(w, r, Created("", domains.NewPlayerGame(*saved)))
+		return
+	}
+
+	render.Render(w, r, Ok("", domains.NewPlayerGame(*saved)))
+}
+
+func (g *game) hint(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.GameHintPayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrGameInvalidHintPayload)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrGameInvalidHintPayload))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrGameInvalidHintPayload)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrGameInvalidHintPayload))
+		return
+	}
+
+	id, err := ulid.Parse(chi.URLParam(r, "puzzle_id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	session, err := g.session.Get(w, r, true)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	puzzle, err := g.puzzle.Find(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	// Continue the user's saved game or start a new one if there isn't one yet
+	game, err := g.service.Start(r.Context(), *puzzle, *session.User)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+	status := http.StatusOK
+	if game.Revision == 0 {
+		status = http.StatusCreated
+	}
+
+	saved, err := g.service.Hint(r.Context(), *game, payload)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	if status == http.StatusCreated {
+		render.Render(w, r, Created("", domains.NewPlayerGame(*saved)))
+		return
+	}
+
+	render.Render(w, r, Ok("", domains.NewPlayerGame(*saved)))
 }
 
 func (g *game) history(w http.ResponseWriter, r *http.Request) {
@@ -174,27 +339,52 @@ func (g *game) save(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Check if the user already has a game saved
+	game, err := g.service.Start(r.Context(), *puzzle, *session.User)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
 	// Create a new game
 	newGame := domains.NewGame()
-	// Append payload to game
-	newGame.Score = payload.Score
-	newGame.Attempts = payload.Attempts
-	newGame.Correct = payload.Correct
-	newGame.CompletedAt = payload.CompletedAt
+	newGame.Revision = game.Revision
 	// Append puzzle
 	newGame.PuzzleID = puzzle.ID
 	newGame.Puzzle = *puzzle
 	// Append user
 	newGame.UserID = session.User.ID
 	newGame.User = *session.User
+	// Hints can only be used through the server so the saved game's hints are kept rather than trusted from the client
+	newGame.Hints = append(newGame.Hints, game.Hints...)
+	// Replay the payload's attempts so that the score, correct, and, completion are decided by the server rather than
+	// trusted from the client. Attempts sent after the game has been completed are ignored
+	for _, attempt := range payload.Attempts {
+		if !newGame.CompletedAt.IsZero() {
+			break
+		}
 
-	// Check if the user already has a game saved
-	// - If not, save the given game
+		if err := newGame.Guess(attempt); err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrGameInvalidPayload))
+			return
+		}
+	}
+	// The client completing a game that the server hasn't completed means the user gave up
+	if !payload.CompletedAt.IsZero() {
+		newGame.GiveUp()
+	}
+
+	// - If the user doesn't have a game saved, save the given game
 	// - If so, check if the saved game is ahead of the given game
 	//    - If the saved game has already been completed, has been wrongfully updated, or, is ahead then just respond back with the saved game
 	//    - Else, save the given game and then respond with it
-	game, err := g.service.FindByPuzzleID(r.Context(), id)
-	if err != nil || game == nil {
+	if game.Revision == 0 {
 		saved, err := g.service.Save(r.Context(), newGame)
 		if err != nil {
 			span.SetStatus(codes.Error, "")
@@ -204,12 +394,12 @@ func (g *game) save(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		render.Render(w, r, Created("", saved))
+		render.Render(w, r, Created("", domains.NewPlayerGame(*saved)))
 		return
 	}
 
 	if !game.CompletedAt.IsZero() || !game.IsContinuation(newGame) || game.IsAhead(newGame) {
-		render.Render(w, r, Ok("", game))
+		render.Render(w, r, Ok("", domains.NewPlayerGame(*game)))
 		return
 	}
 
@@ -222,5 +412,42 @@ func (g *game) save(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	render.Render(w, r, Ok("", saved))
+	render.Render(w, r, Ok("", domains.NewPlayerGame(*saved)))
+}
+
+func (g *game) share(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "puzzle_id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	if _, err := g.session.Get(w, r, true); err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	share, err := g.service.Share(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	// Allow clients to get the summary as-is so that it can be copied straight to the clipboard
+	if r.URL.Query().Get("format") == "text" {
+		render.PlainText(w, r, share.Text)
+		return
+	}
+
+	render.Render(w, r, Ok("", share))
 }
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/config"
+	"github.com/RagOfJoes/puzzlely/memory"
+	"github.com/RagOfJoes/puzzlely/services"
+	"github.com/go-chi/chi/v5"
+)
+
+type testServer struct {
+	router *chi.Mux
+	// Bearer token of an authenticated session
+	token string
+}
+
+// Sets up the puzzle and game routes on top of an in-memory store, along with an authenticated session
+func newTestServer(t *testing.T) testServer {
+	t.Helper()
+
+	cfg := config.Configuration{}
+	db := memory.New()
+	ctx := context.Background()
+
+	user := domains.NewUser()
+	created, err := memory.NewUser(db).Create(ctx, domains.NewConnection("github", user.ID, user.ID), user)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	sessionService := services.NewSession(services.SessionDependencies{Repository: memory.NewSession(db)})
+	newSession := domains.NewSession()
+	if err := newSession.Authenticate(time.Now().Add(time.Hour), *created); err != nil {
+		t.Fatalf("failed to authenticate session: %v", err)
+	}
+	authenticated, err := sessionService.New(ctx, newSession)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	session := Session(SessionDependencies{Config: cfg, Service: sessionService})
+	puzzle := services.NewPuzzle(services.PuzzleDependencies{Game: memory.NewGame(db), Repository: memory.NewPuzzle(db)})
+
+	router := New(cfg)
+	Puzzle(PuzzleDependencies{Config: cfg, Service: puzzle, Session: session}, router)
+	Game(GameDependencies{
+		Config: cfg,
+
+		Puzzle:  puzzle,
+		Service: services.NewGame(services.GameDependencies{Repository: memory.NewGame(db)}),
+		User:    services.NewUser(services.UserDependencies{Config: cfg, Repository: memory.NewUser(db)}),
+
+		Session: session,
+	}, router)
+
+	return testServer{
+		router: router,
+		token:  authenticated.ID,
+	}
+}
+
+// Sends the request and decodes the response's data into `data`. An empty token sends the request without a session
+func (s testServer) do(t *testing.T, method, path, token, body string, data any) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	if data != nil && w.Code < http.StatusBadRequest {
+		response := struct {
+			Data any `json:"data"`
+		}{
+			Data: data,
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response of %s %s: %v", method, path, err)
+		}
+	}
+
+	return w.Code
+}
+
+// Creates a puzzle with 3 groups of 3 blocks
+func (s testServer) createPuzzle(t *testing.T) domains.Puzzle {
+	t.Helper()
+
+	payload := domains.PuzzleCreatePayload{
+		Difficulty:  "EASY",
+		MaxAttempts: 2,
+	}
+	for i := range 3 {
+		group := domains.PuzzleCreatePayloadGroup{
+			Description: fmt.Sprintf("Group %d", i),
+		}
+		for j := range 3 {
+			group.Blocks = append(group.Blocks, domains.PuzzleCreatePayloadBlock{Value: fmt.Sprintf("Block %d-%d", i, j)})
+		}
+
+		payload.Groups = append(payload.Groups, group)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to encode puzzle: %v", err)
+	}
+
+	var puzzle domains.Puzzle
+	if code := s.do(t, http.MethodPost, "/puzzles/create", s.token, string(body), &puzzle); code != http.StatusCreated {
+		t.Fatalf("expected puzzle to be created, got %d", code)
+	}
+
+	return puzzle
+}
+
+func TestPuzzleHidesUnsolvedGroups(t *testing.T) {
+	server := newTestServer(t)
+	puzzle := server.createPuzzle(t)
+
+	var before domains.PlayerPuzzle
+	if code := server.do(t, http.MethodGet, "/puzzles/"+puzzle.ID, "", "", &before); code != http.StatusOK {
+		t.Fatalf("expected puzzle to be found, got %d", code)
+	}
+	if len(before.Blocks) != 9 || len(before.Groups) != 0 {
+		t.Fatalf("expected 9 blocks and no groups, got %d blocks and %d groups", len(before.Blocks), len(before.Groups))
+	}
+
+	var blocks []string
+	for _, block := range puzzle.Groups[0].Blocks {
+		blocks = append(blocks, block.ID)
+	}
+	body, _ := json.Marshal(domains.GameGuessPayload{Blocks: blocks})
+	if code := server.do(t, http.MethodPost, "/games/"+puzzle.ID+"/guess", server.token, string(body), nil); code != http.StatusCreated {
+		t.Fatalf("expected game to be created, got %d", code)
+	}
+
+	var after domains.PlayerPuzzle
+	if code := server.do(t, http.MethodGet, "/puzzles/"+puzzle.ID, server.token, "", &after); code != http.StatusOK {
+		t.Fatalf("expected puzzle to be found, got %d", code)
+	}
+	if len(after.Groups) != 1 || after.Groups[0].ID != puzzle.Groups[0].ID || after.Groups[0].Description != puzzle.Groups[0].Description {
+		t.Fatalf("expected only the solved group, got %+v", after.Groups)
+	}
+}
+
+func TestGameGuess(t *testing.T) {
+	server := newTestServer(t)
+	puzzle := server.createPuzzle(t)
+
+	groupBlocks := func(group int) []string {
+		blocks := make([]string, 0)
+		for _, block := range puzzle.Groups[group].Blocks {
+			blocks = append(blocks, block.ID)
+		}
+
+		return blocks
+	}
+	wrong := []string{puzzle.Groups[1].Blocks[0].ID, puzzle.Groups[1].Blocks[1].ID, puzzle.Groups[2].Blocks[0].ID}
+
+	tests := []struct {
+		name   string
+		token  string
+		blocks []string
+
+		code     int
+		attempts int
+		groups   int
+	}{
+		{name: "without a session", blocks: wrong, code: http.StatusUnauthorized},
+		{name: "wrong guess", token: server.token, blocks: wrong, code: http.StatusCreated, attempts: 1},
+		{name: "correct guess", token: server.token, blocks: groupBlocks(0), code: http.StatusOK, attempts: 2, groups: 1},
+		{name: "already solved group", token: server.token, blocks: groupBlocks(0), code: http.StatusBadRequest},
+		{name: "unknown block", token: server.token, blocks: append(groupBlocks(1)[:2], puzzle.ID), code: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(domains.GameGuessPayload{Blocks: tt.blocks})
+
+			var game domains.PlayerGame
+			code := server.do(t, http.MethodPost, "/games/"+puzzle.ID+"/guess", tt.token, string(body), &game)
+			if code != tt.code {
+				t.Fatalf("expected %d, got %d", tt.code, code)
+			}
+			if code >= http.StatusBadRequest {
+				return
+			}
+
+			if len(game.Attempts) != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, len(game.Attempts))
+			}
+			if len(game.Puzzle.Groups) != tt.groups {
+				t.Errorf("expected %d revealed groups, got %d", tt.groups, len(game.Puzzle.Groups))
+			}
+		})
+	}
+}
+
+func TestGameGetWithoutSession(t *testing.T) {
+	server := newTestServer(t)
+	puzzle := server.createPuzzle(t)
+
+	if code := server.do(t, http.MethodGet, "/games/"+puzzle.ID, "", "", nil); code != http.StatusUnauthorized {
+		t.Fatalf("expected %d, got %d", http.StatusUnauthorized, code)
+	}
+	if code := server.do(t, http.MethodGet, "/games/"+puzzle.ID, server.token, "", nil); code != http.StatusNotFound {
+		t.Fatalf("expected %d, got %d", http.StatusNotFound, code)
+	}
+}
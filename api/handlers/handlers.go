@@ -15,6 +15,7 @@ import (
 	"github.com/RagOfJoes/puzzlely/internal/telemetry"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
 	"github.com/go-chi/render"
 	"github.com/riandyrn/otelchi"
 	"github.com/sirupsen/logrus"
@@ -42,14 +43,17 @@ func New(cfg config.Configuration) *chi.Mux {
 	// Security Headers Middlewares
 	router.Use(render.SetContentType(render.ContentTypeJSON))
 	router.Use(secure.New(cfg.Server.Security).Handler)
-	// router.Use(cors.Handler(cors.Options{
-	// 	AllowCredentials: cfg.Server.AccessControl.AllowCredentials,
-	// 	AllowedHeaders:   cfg.Server.AccessControl.AllowHeaders,
-	// 	AllowedMethods:   cfg.Server.AccessControl.AllowMethods,
-	// 	AllowedOrigins:   cfg.Server.AccessControl.AllowedOrigins,
-	// 	ExposedHeaders:   cfg.Server.AccessControl.ExposeHeaders,
-	// 	MaxAge:           int(cfg.Server.AccessControl.MaxAge),
-	// }))
+	// CORS is only enabled when there are origins to allow since `cors` would otherwise allow every origin
+	if len(cfg.Server.AccessControl.AllowedOrigins) > 0 {
+		router.Use(cors.Handler(cors.Options{
+			AllowCredentials: cfg.Server.AccessControl.AllowCredentials,
+			AllowedHeaders:   cfg.Server.AccessControl.AllowHeaders,
+			AllowedMethods:   cfg.Server.AccessControl.AllowMethods,
+			AllowedOrigins:   cfg.Server.AccessControl.AllowedOrigins,
+			ExposedHeaders:   cfg.Server.AccessControl.ExposeHeaders,
+			MaxAge:           cfg.Server.AccessControl.MaxAge,
+		}))
+	}
 
 	// Opentelemetry middleware
 	// Traces incoming requests
@@ -97,6 +101,8 @@ func New(cfg config.Configuration) *chi.Mux {
 				render.Render(w, r, NotFound(err))
 			case internal.ErrorCodeMethodNotAllowed:
 				render.Render(w, r, MethodNotAllowed(err))
+			case internal.ErrorCodeTooManyRequests:
+				render.Render(w, r, TooManyRequests(err))
 			default:
 				render.Render(w, r, internalErr)
 			}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/oklog/ulid/v2"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrModerationInvalidPayload = errors.New("Invalid action provided.")
+)
+
+type moderation struct {
+	service services.Report
+
+	session session
+}
+
+type ModerationDependencies struct {
+	Service services.Report
+
+	Session session
+}
+
+func Moderation(dependencies ModerationDependencies, router *chi.Mux) {
+	m := &moderation{
+		service: dependencies.Service,
+
+		session: dependencies.Session,
+	}
+
+	router.Route("/admin/reports", func(r chi.Router) {
+		r.Use(Authorize(m.session, domains.PermissionReviewReports))
+
+		r.Get("/", m.queue)
+
+		r.Put("/{id}", m.resolve)
+	})
+}
+
+func (m *moderation) queue(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	cursor, err := domains.CursorFromString(r.URL.Query().Get("cursor"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	opts := domains.ReportCursorPaginationOpts{
+		Cursor: cursor,
+		Limit:  20,
+	}
+	connection, err := m.service.FindQueue(r.Context(), opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", connection))
+}
+
+func (m *moderation) resolve(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.ReportResolvePayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrModerationInvalidPayload)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrModerationInvalidPayload))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrModerationInvalidPayload)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	report, err := m.service.Resolve(r.Context(), id, payload)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", report))
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/config"
+	"github.com/RagOfJoes/puzzlely/internal/og"
+	"github.com/RagOfJoes/puzzlely/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/oklog/ulid/v2"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrOGRender = errors.New("Failed to render image.")
+)
+
+// How long, in seconds, clients and crawlers can cache an image for. Puzzle cards include the number of likes so
+// they're kept for a shorter period than result cards, which don't change once a game is completed
+const (
+	ogGameMaxAge   = 60 * 60 * 24
+	ogPuzzleMaxAge = 60 * 60
+)
+
+// Maximum number of rendered images that are kept in memory
+const ogCacheSize = 256
+
+// Keeps rendered images, keyed by everything that they're drawn from, so that the same image isn't drawn again for
+// every crawler that requests it. The oldest image is evicted once the cache is full
+type ogCache struct {
+	mu sync.Mutex
+
+	images map[string][]byte
+	// Keys of the cached images, oldest first
+	keys []string
+}
+
+func (c *ogCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	image, ok := c.images[key]
+	return image, ok
+}
+
+func (c *ogCache) set(key string, image []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.images[key]; ok {
+		return
+	}
+	if len(c.keys) >= ogCacheSize {
+		delete(c.images, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+
+	c.images[key] = image
+	c.keys = append(c.keys, key)
+}
+
+type openGraph struct {
+	config config.Configuration
+
+	cache  *ogCache
+	game   services.Game
+	puzzle services.Puzzle
+
+	session session
+}
+
+type OpenGraphDependencies struct {
+	Config config.Configuration
+
+	Game   services.Game
+	Puzzle services.Puzzle
+
+	Session session
+}
+
+func OpenGraph(dependencies OpenGraphDependencies, router *chi.Mux) {
+	o := &openGraph{
+		config: dependencies.Config,
+
+		cache: &ogCache{
+			images: make(map[string][]byte),
+		},
+		game:   dependencies.Game,
+		puzzle: dependencies.Puzzle,
+
+		session: dependencies.Session,
+	}
+
+	router.Route("/og", func(r chi.Router) {
+		r.Use(RateLimit(o.config.Server.RateLimits.OG, o.session))
+
+		r.Get("/games/{id}.png", o.getGame)
+		r.Get("/puzzles/{id}.png", o.getPuzzle)
+	})
+}
+
+func (o *openGraph) getGame(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	game, err := o.game.Find(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+	// Games that are still in progress can't be shared yet
+	if game.CompletedAt.IsZero() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(services.ErrGameNotCompleted)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeNotFound, "%v", services.ErrGameNotFound))
+		return
+	}
+
+	// Completed games don't change, other than their player's username
+	key := fmt.Sprintf("game:%s:%d:%s", game.ID, game.Revision, game.User.Username)
+	if image, ok := o.cache.get(key); ok {
+		o.respond(w, r, image, ogGameMaxAge)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := og.Game(&buf, *game); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrOGRender))
+		return
+	}
+	o.cache.set(key, buf.Bytes())
+
+	o.respond(w, r, buf.Bytes(), ogGameMaxAge)
+}
+
+func (o *openGraph) getPuzzle(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	puzzle, err := o.puzzle.Find(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	key := fmt.Sprintf("puzzle:%s:%d:%d:%s", puzzle.ID, puzzle.Version, puzzle.NumOfLikes, puzzle.CreatedBy.Username)
+	if image, ok := o.cache.get(key); ok {
+		o.respond(w, r, image, ogPuzzleMaxAge)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := og.Puzzle(&buf, *puzzle); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrOGRender))
+		return
+	}
+	o.cache.set(key, buf.Bytes())
+
+	o.respond(w, r, buf.Bytes(), ogPuzzleMaxAge)
+}
+
+// Writes the image along with its caching headers. The ETag is derived from the image itself so that crawlers can
+// revalidate an expired image without downloading it again
+func (o *openGraph) respond(w http.ResponseWriter, r *http.Request, image []byte, maxAge int) {
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(image))
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(image)))
+	w.Header().Set("Content-Type", "image/png")
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
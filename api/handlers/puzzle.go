@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/RagOfJoes/puzzlely/domains"
 	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/config"
 	"github.com/RagOfJoes/puzzlely/services"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -18,16 +23,28 @@ import (
 var (
 	ErrPuzzleCursorPaginationOpts = errors.New("Invalid cursor pagination options provided.")
 	ErrPuzzleInvalidCreatePayload = errors.New("Invalid new puzzle provided.")
+	ErrPuzzleInvalidFile          = errors.New("Invalid puzzle file provided.")
+	ErrPuzzleInvalidReport        = errors.New("Invalid report provided.")
+	ErrPuzzleInvalidSearch        = errors.New("Invalid search provided.")
 	ErrPuzzleInvalidUpdatePayload = errors.New("Invalid puzzle provided.")
 )
 
+// Largest puzzle file, in bytes, that can be imported
+const puzzleFileMaxBytes = 1 << 20
+
 type puzzle struct {
+	config config.Configuration
+
+	report  services.Report
 	service services.Puzzle
 
 	session session
 }
 
 type PuzzleDependencies struct {
+	Config config.Configuration
+
+	Report  services.Report
 	Service services.Puzzle
 
 	Session session
@@ -35,21 +52,38 @@ type PuzzleDependencies struct {
 
 func Puzzle(dependencies PuzzleDependencies, router *chi.Mux) {
 	p := &puzzle{
+		config: dependencies.Config,
+
+		report:  dependencies.Report,
 		service: dependencies.Service,
 
 		session: dependencies.Session,
 	}
 
+	limit := RateLimit(p.config.Server.RateLimits.Puzzles, p.session)
+
+	create := Authorize(p.session, domains.PermissionCreatePuzzles)
+	report := Authorize(p.session, domains.PermissionReport)
+
 	router.Route("/puzzles", func(r chi.Router) {
-		r.Post("/create", p.create)
+		r.With(limit, create).Post("/create", p.create)
+		r.With(limit, create).Post("/import", p.importFile)
+		r.With(limit, report).Post("/{id}/report", p.reportPuzzle)
 
 		r.Get("/{id}", p.puzzle)
+		r.Get("/{id}/export", p.export)
+		r.Get("/{id}/leaderboard", p.leaderboard)
+		r.Get("/{id}/versions", p.versions)
 		r.Get("/created/{user_id}", p.created)
+		r.Get("/edit/{id}", p.edit)
 		r.Get("/liked/{user_id}", p.liked)
 		r.Get("/recent", p.recent)
+		r.Get("/search", p.search)
+
+		r.With(limit).Put("/like/{id}", p.toggleLike)
+		r.With(limit, create).Put("/update/{id}", p.update)
 
-		r.Put("/like/{id}", p.toggleLike)
-		r.Put("/update/{id}", p.create)
+		r.With(limit).Delete("/{id}", p.delete)
 	})
 }
 
@@ -72,13 +106,7 @@ func (p *puzzle) create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session, err := p.session.Get(w, r, true)
-	if err != nil {
-		span.SetStatus(codes.Error, "")
-
-		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
-		return
-	}
+	session := domains.SessionFromContext(r.Context())
 
 	newPuzzle := payload.ToPuzzle()
 	newPuzzle.CreatedBy = *session.User
@@ -144,6 +172,195 @@ func (p *puzzle) created(w http.ResponseWriter, r *http.Request) {
 	render.Render(w, r, Ok("", connection))
 }
 
+func (p *puzzle) delete(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	if _, err := p.session.Get(w, r, true); err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	if err := p.service.Delete(r.Context(), id); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", true))
+}
+
+func (p *puzzle) edit(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	if _, err := p.session.Get(w, r, true); err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	puzzle, err := p.service.FindForEdit(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", puzzle))
+}
+
+func (p *puzzle) export(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	if _, err := p.session.Get(w, r, true); err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	// Only the creator can export a puzzle since the file includes every group and block
+	puzzle, err := p.service.FindForEdit(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	file := domains.NewPuzzleFile(*puzzle)
+
+	var buf bytes.Buffer
+	extension := "json"
+	contentType := "application/json"
+	if r.URL.Query().Get("format") == "csv" {
+		extension = "csv"
+		contentType = "text/csv; charset=utf-8"
+
+		err = file.WriteCSV(&buf)
+	} else {
+		err = file.WriteJSON(&buf)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", services.ErrPuzzleNotFound))
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="puzzle-%s.%s"`, puzzle.ID, extension))
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func (p *puzzle) importFile(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	// Files are sent as the request's body with its content type deciding which variant is used
+	body := http.MaxBytesReader(w, r.Body, puzzleFileMaxBytes)
+
+	var err error
+	var file domains.PuzzleFile
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		file, err = domains.ParsePuzzleFileCSV(body)
+	} else {
+		file, err = domains.ParsePuzzleFileJSON(body)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v: %v", ErrPuzzleInvalidFile, err))
+		return
+	}
+
+	result, err := p.service.Import(r.Context(), file)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Created("", result))
+}
+
+func (p *puzzle) leaderboard(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	cursor, err := domains.CursorFromString(r.URL.Query().Get("cursor"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	p.session.Get(w, r, false)
+
+	opts := domains.LeaderboardOpts{
+		Cursor: cursor,
+		Limit:  20,
+	}
+	connection, err := p.service.FindLeaderboard(r.Context(), id, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", connection))
+}
+
 func (p *puzzle) liked(w http.ResponseWriter, r *http.Request) {
 	span := trace.SpanFromContext(r.Context())
 
@@ -199,7 +416,7 @@ func (p *puzzle) puzzle(w http.ResponseWriter, r *http.Request) {
 
 	p.session.Get(w, r, false)
 
-	puzzle, err := p.service.Find(r.Context(), id)
+	puzzle, err := p.service.FindForPlayer(r.Context(), id)
 	if err != nil {
 		span.SetStatus(codes.Error, "")
 		span.RecordError(err)
@@ -229,6 +446,8 @@ func (p *puzzle) recent(w http.ResponseWriter, r *http.Request) {
 		Cursor:    cursor,
 		Direction: r.URL.Query().Get("direction"),
 		Limit:     1,
+
+		Language: r.URL.Query().Get("language"),
 	}
 	connection, err := p.service.FindRecent(r.Context(), opts)
 	if err != nil {
@@ -242,6 +461,100 @@ func (p *puzzle) recent(w http.ResponseWriter, r *http.Request) {
 	render.Render(w, r, Ok("", connection))
 }
 
+func (p *puzzle) reportPuzzle(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.ReportPayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleInvalidReport)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrPuzzleInvalidReport))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleInvalidReport)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	report, err := p.report.NewForPuzzle(r.Context(), id, payload)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Created("", report))
+}
+
+func (p *puzzle) search(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	query := r.URL.Query()
+
+	cursor, err := domains.CursorFromString(query.Get("cursor"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	unplayed := false
+	if query.Has("unplayed") {
+		parsed, err := strconv.ParseBool(query.Get("unplayed"))
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(ErrPuzzleInvalidSearch)
+
+			render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrPuzzleInvalidSearch))
+			return
+		}
+
+		unplayed = parsed
+	}
+
+	// Get the session from the request and pass result, if any, to the context
+	p.session.Get(w, r, false)
+
+	opts := domains.PuzzleSearchOpts{
+		Query: internal.Normalize(query.Get("q")),
+
+		Difficulty: query.Get("difficulty"),
+		Language:   query.Get("language"),
+		UserID:     query.Get("user_id"),
+		Unplayed:   unplayed,
+
+		Cursor: cursor,
+		Limit:  12,
+	}
+	connection, err := p.service.Search(r.Context(), opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", connection))
+}
+
 func (p *puzzle) toggleLike(w http.ResponseWriter, r *http.Request) {
 	span := trace.SpanFromContext(r.Context())
 
@@ -273,61 +586,138 @@ func (p *puzzle) toggleLike(w http.ResponseWriter, r *http.Request) {
 	render.Render(w, r, Ok("", like))
 }
 
-// func (p *puzzle) update(w http.ResponseWriter, r *http.Request) {
-// 	var payload domains.PuzzleUpdatePayload
-// 	if err := render.Bind(r, &payload); err != nil {
-// 		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrPuzzleInvalidUpdatePayload))
-// 		return
-// 	}
-// 	if err := payload.Validate(); err != nil {
-// 		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err))
-// 		return
-// 	}
-//
-// 	id, err := ulid.Parse(chi.URLParam(r, "id"))
-// 	if err != nil {
-// 		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
-// 		return
-// 	}
-//
-// 	if _, err := p.session.Get(w, r, true); err != nil {
-// 		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
-// 		return
-// 	}
-//
-// 	puzzle, err := p.service.Find(r.Context(), id)
-// 	if err != nil {
-// 		render.Respond(w, r, err)
-// 		return
-// 	}
-//
-// 	if payload.Difficulty == puzzle.Difficulty && len(payload.Groups) == 0 {
-// 		render.Render(w, r, Ok("", puzzle))
-// 		return
-// 	}
-//
-// 	update := *puzzle
-// 	update.Difficulty = payload.Difficulty
-//
-// 	groups := map[string]domains.PuzzleUpdatePayloadGroup{}
-// 	for _, group := range payload.Groups {
-// 		groups[group.ID] = group
-// 	}
-//
-// 	for i, group := range update.Groups {
-// 		value, ok := groups[group.ID]
-// 		if !ok {
-// 			continue
-// 		}
-//
-// 		update.Groups[i].Description = value.Description
-// 	}
-//
-// 	updated, err := p.service.Update(r.Context(), *puzzle, update)
-// 	if err != nil {
-// 		render.Respond(w, r, err)
-// 		return
-// 	}
-//
-// 	render.Render(w, r, Ok("", updated))
-// }
+func (p *puzzle) update(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.PuzzleUpdatePayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleInvalidUpdatePayload)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrPuzzleInvalidUpdatePayload))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleInvalidUpdatePayload)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	// Make sure the user owns the puzzle before anything, including the unchanged puzzle, is sent back
+	puzzle, err := p.service.FindForEdit(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	groups := map[string]domains.PuzzleUpdatePayloadGroup{}
+	for _, group := range payload.Groups {
+		groups[group.ID] = group
+	}
+
+	// Keep the tags that the puzzle already has so that only new tags are created
+	existing := map[string]domains.Tag{}
+	for _, tag := range puzzle.Tags {
+		existing[tag.Slug] = tag
+	}
+
+	tags := puzzle.Tags
+	if payload.Tags != nil {
+		tags = make([]domains.Tag, 0, len(payload.Tags))
+		for _, name := range payload.Tags {
+			tag, ok := existing[domains.TagSlug(name)]
+			if !ok {
+				tag = domains.NewTag(name)
+			}
+
+			tags = append(tags, tag)
+		}
+	}
+
+	// If no changes were made
+	isChanged := payload.Difficulty != puzzle.Difficulty || len(tags) != len(puzzle.Tags)
+	for _, tag := range tags {
+		if _, ok := existing[tag.Slug]; !ok {
+			isChanged = true
+		}
+	}
+	for _, group := range puzzle.Groups {
+		if value, ok := groups[group.ID]; ok && value.Description != group.Description {
+			isChanged = true
+		}
+	}
+	if !isChanged {
+		render.Render(w, r, Ok("", puzzle))
+		return
+	}
+
+	update := *puzzle
+	update.Difficulty = payload.Difficulty
+	update.Tags = tags
+	update.Groups = make([]domains.PuzzleGroup, len(puzzle.Groups))
+	copy(update.Groups, puzzle.Groups)
+	for i, group := range update.Groups {
+		value, ok := groups[group.ID]
+		if !ok {
+			continue
+		}
+
+		update.Groups[i].Description = value.Description
+	}
+
+	updated, err := p.service.Update(r.Context(), *puzzle, update)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", updated))
+}
+
+func (p *puzzle) versions(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrInvalidID))
+		return
+	}
+
+	if _, err := p.session.Get(w, r, true); err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	versions, err := p.service.FindVersions(r.Context(), id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", versions))
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/config"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrTooManyRequests = errors.New("You're doing that too often. Please try again later.")
+)
+
+// Token bucket for a single caller
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+type rateLimiter struct {
+	mu sync.Mutex
+
+	// Number of tokens that are refilled every second
+	rate float64
+	size float64
+
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+// Takes a token from the caller's bucket. If the bucket is empty then how long the caller must wait for a token is
+// returned instead
+func (l *rateLimiter) take(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cleanup(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens:  l.size,
+			updated: now,
+		}
+		l.buckets[key] = b
+	}
+
+	// Refill the bucket with the tokens accumulated since it was last used
+	b.tokens = math.Min(l.size, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+
+	b.tokens -= 1
+
+	return true, 0
+}
+
+// Removes buckets that would be full by now since they're no different from a new bucket
+//
+// NOTE: Expects the caller to hold the lock
+func (l *rateLimiter) cleanup(now time.Time) {
+	interval := time.Duration(l.size / l.rate * float64(time.Second))
+	if now.Sub(l.lastCleanup) < interval {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.rate >= l.size {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// RateLimit limits requests with a token bucket per caller. Authenticated callers are limited by their user id and
+// anonymous callers by their IP address, which relies on `middleware.RealIP`
+func RateLimit(cfg config.RateLimit, session session) func(http.Handler) http.Handler {
+	// Limit is disabled
+	if cfg.Requests <= 0 {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	limiter := &rateLimiter{
+		rate: float64(cfg.Requests) / cfg.Interval.Seconds(),
+		size: float64(cfg.Requests),
+
+		buckets:     make(map[string]*bucket),
+		lastCleanup: time.Now(),
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			span := trace.SpanFromContext(r.Context())
+
+			// Get the session from the request and pass result, if any, to the context
+			session.Get(w, r, false)
+
+			key := "ip:" + remoteIP(r)
+			if s := domains.SessionFromContext(r.Context()); s != nil && s.IsAuthenticated() {
+				key = "user:" + s.UserID.String
+			}
+
+			ok, wait := limiter.take(key, time.Now())
+			if !ok {
+				span.SetStatus(codes.Error, "")
+				span.RecordError(ErrTooManyRequests)
+
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeTooManyRequests, "%v", ErrTooManyRequests))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Strips the port, if any, from the request's remote address
+func remoteIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return ip
+}
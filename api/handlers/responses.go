@@ -106,6 +106,16 @@ func MethodNotAllowed(err error) render.Renderer {
 	}
 }
 
+// TooManyRequests creates a response with a HTTP 429 status
+func TooManyRequests(err error) render.Renderer {
+	return &Response{
+		status: http.StatusTooManyRequests,
+
+		Success: false,
+		Error:   err,
+	}
+}
+
 // InternalServerError creates a response with a HTTP 500 status
 func InternalServerError(err error) render.Renderer {
 	return &Response{
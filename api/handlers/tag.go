@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrTagInvalidSlug = errors.New("Invalid tag provided.")
+)
+
+type tag struct {
+	puzzle  services.Puzzle
+	service services.Tag
+
+	session session
+}
+
+type TagDependencies struct {
+	Puzzle  services.Puzzle
+	Service services.Tag
+
+	Session session
+}
+
+func Tag(dependencies TagDependencies, router *chi.Mux) {
+	t := &tag{
+		puzzle:  dependencies.Puzzle,
+		service: dependencies.Service,
+
+		session: dependencies.Session,
+	}
+
+	router.Route("/tags", func(r chi.Router) {
+		r.Get("/", t.search)
+		r.Get("/{slug}/puzzles", t.puzzles)
+	})
+}
+
+func (t *tag) puzzles(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	// First make sure that the request is valid. Slugs can contain any letter so they may be percent-encoded
+	slug, err := url.PathUnescape(chi.URLParam(r, "slug"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrTagInvalidSlug)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrTagInvalidSlug))
+		return
+	}
+
+	cursor, err := domains.CursorFromString(r.URL.Query().Get("cursor"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	found, err := t.service.Find(r.Context(), domains.TagSlug(slug))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	// Get the session from the request and pass result, if any, to the context
+	t.session.Get(w, r, false)
+
+	opts := domains.PuzzleCursorPaginationOpts{
+		Cursor: cursor,
+		Limit:  12,
+	}
+	connection, err := t.puzzle.FindTagged(r.Context(), found.Slug, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", connection))
+}
+
+func (t *tag) search(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	opts := domains.TagSearchOpts{
+		Query: internal.Normalize(r.URL.Query().Get("q")),
+		Limit: 10,
+	}
+	tags, err := t.service.Search(r.Context(), opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", tags))
+}
@@ -15,15 +15,19 @@ import (
 )
 
 var (
+	ErrUserInvalidReport        = errors.New("Invalid report provided.")
+	ErrUserInvalidRolePayload   = errors.New("Invalid role provided.")
 	ErrUserInvalidUpdatePayload = errors.New("Invalid update provided.")
 )
 
 type user struct {
+	report  services.Report
 	service services.User
 	session session
 }
 
 type UserDependencies struct {
+	Report  services.Report
 	Service services.User
 
 	Session session
@@ -31,6 +35,7 @@ type UserDependencies struct {
 
 func User(dependencies UserDependencies, router *chi.Mux) {
 	u := &user{
+		report:  dependencies.Report,
 		service: dependencies.Service,
 		session: dependencies.Session,
 	}
@@ -38,11 +43,45 @@ func User(dependencies UserDependencies, router *chi.Mux) {
 	router.Get("/me", u.me)
 	router.Route("/users", func(r chi.Router) {
 		r.Get("/{id}", u.get)
+		r.Get("/{id}/stats", u.stats)
+
+		r.With(Authorize(u.session, domains.PermissionReport)).Post("/{id}/report", u.reportUser)
 
 		r.Put("/", u.update)
+
+		r.Delete("/me", u.delete)
+	})
+
+	router.Route("/admin/users", func(r chi.Router) {
+		r.Use(Authorize(u.session, domains.PermissionManageRoles))
+
+		r.Put("/{id}/role", u.role)
 	})
 }
 
+func (u *user) delete(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	session, err := u.session.Get(w, r, true)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized))
+		return
+	}
+
+	// NOTE: This also removes all of the user's sessions, including the current one
+	if err := u.service.Delete(r.Context(), session.User.ID); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", true))
+}
+
 func (u *user) get(w http.ResponseWriter, r *http.Request) {
 	span := trace.SpanFromContext(r.Context())
 
@@ -66,6 +105,16 @@ func (u *user) get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	streak, err := u.service.FindStreak(r.Context(), *user)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+	user.Streak = streak
+
 	render.Render(w, r, Ok("", user))
 }
 
@@ -80,9 +129,126 @@ func (u *user) me(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	streak, err := u.service.FindStreak(r.Context(), *session.User)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+	session.User.Streak = streak
+	session.User.Permissions = domains.RolePermissions(session.User.Role)
+
 	render.Render(w, r, Ok("", session))
 }
 
+func (u *user) reportUser(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.ReportPayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrUserInvalidReport))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrInvalidID))
+		return
+	}
+
+	report, err := u.report.NewForUser(r.Context(), id, payload)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Created("", report))
+}
+
+func (u *user) role(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	var payload domains.UserRolePayload
+	if err := render.Bind(r, &payload); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrUserInvalidRolePayload))
+		return
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err))
+		return
+	}
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrInvalidID))
+		return
+	}
+
+	user, err := u.service.UpdateRole(r.Context(), id.String(), payload)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", user))
+}
+
+func (u *user) stats(w http.ResponseWriter, r *http.Request) {
+	span := trace.SpanFromContext(r.Context())
+
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrInvalidID)
+
+		render.Respond(w, r, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrInvalidID))
+		return
+	}
+
+	u.session.Get(w, r, false)
+
+	stats, err := u.service.FindStats(r.Context(), id.String())
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		render.Respond(w, r, err)
+		return
+	}
+
+	render.Render(w, r, Ok("", stats))
+}
+
 func (u *user) update(w http.ResponseWriter, r *http.Request) {
 	span := trace.SpanFromContext(r.Context())
 
@@ -110,14 +276,20 @@ func (u *user) update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	timezone := session.User.Timezone
+	if payload.Timezone != "" {
+		timezone = payload.Timezone
+	}
+
 	// If no changes were made
-	if session.User.IsComplete() && payload.Username == session.User.Username {
+	if session.User.IsComplete() && payload.Username == session.User.Username && timezone == session.User.Timezone {
 		render.Render(w, r, Ok("", session.User))
 		return
 	}
 
 	update := *session.User
 	update.Username = payload.Username
+	update.Timezone = timezone
 
 	user, err := u.service.Update(r.Context(), update)
 	if err != nil {
@@ -0,0 +1,231 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal/config"
+	"github.com/RagOfJoes/puzzlely/migrations"
+	"github.com/RagOfJoes/puzzlely/postgres"
+	"github.com/sirupsen/logrus"
+	bunmigrate "github.com/uptrace/bun/migrate"
+)
+
+const (
+	// Directory, relative to the api folder, where new migrations are created
+	directory = "migrations"
+
+	locksTable = "schema_migration_locks"
+	table      = "schema_migrations"
+)
+
+// Errors
+var (
+	ErrMigrateInvalidName      = errors.New("Migration name must only contain lowercase letters, numbers, hyphens, and underscores.")
+	ErrMigrateMissingBaseline  = errors.New("Must provide the last migration that the database already has, e.g. 20240907000001_user.")
+	ErrMigrateMissingName      = errors.New("Must provide a name for the new migration.")
+	ErrMigrateUnknownMigration = errors.New("Migration does not exist or has already been applied.")
+	ErrMigrateUnsupported      = errors.New("Migrations can only be applied to a Postgres database.")
+	ErrMigrateUsage            = errors.New("Usage: migrate <up|down|status|baseline|create> [name]")
+)
+
+var (
+	regName = regexp.MustCompile("^[0-9a-z_-]+$")
+)
+
+// Run runs the migrate subcommand with the given arguments
+func Run(args []string) error {
+	if len(args) == 0 {
+		return ErrMigrateUsage
+	}
+
+	// Creating a migration only touches the filesystem so there's no need to connect to the database
+	if args[0] == "create" {
+		if len(args) < 2 {
+			return ErrMigrateMissingName
+		}
+
+		return create(args[1])
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		return err
+	}
+	if cfg.Database.Driver == config.DatabaseDriverMemory {
+		return ErrMigrateUnsupported
+	}
+
+	db, err := postgres.Connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	collection := bunmigrate.NewMigrations()
+	if err := collection.Discover(migrations.FS); err != nil {
+		return err
+	}
+
+	migrator := bunmigrate.NewMigrator(db, collection,
+		bunmigrate.WithTableName(table),
+		bunmigrate.WithLocksTableName(locksTable),
+		bunmigrate.WithMarkAppliedOnSuccess(true),
+	)
+
+	ctx := context.Background()
+	if err := migrator.Init(ctx); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		return up(ctx, migrator)
+	case "down":
+		return down(ctx, migrator)
+	case "status":
+		return status(ctx, migrator)
+	case "baseline":
+		if len(args) < 2 {
+			return ErrMigrateMissingBaseline
+		}
+
+		return baseline(ctx, migrator, args[1])
+	default:
+		return ErrMigrateUsage
+	}
+}
+
+// Applies every migration that hasn't been applied yet
+func up(ctx context.Context, migrator *bunmigrate.Migrator) error {
+	if err := migrator.Lock(ctx); err != nil {
+		return err
+	}
+	defer migrator.Unlock(ctx)
+
+	group, err := migrator.Migrate(ctx)
+	if err != nil {
+		return err
+	}
+	if group.IsZero() {
+		logrus.Info("[Migrate] No new migrations to apply")
+		return nil
+	}
+
+	logrus.Infof("[Migrate] Applied %s", group)
+
+	return nil
+}
+
+// Rolls back the last group of migrations that were applied together
+func down(ctx context.Context, migrator *bunmigrate.Migrator) error {
+	if err := migrator.Lock(ctx); err != nil {
+		return err
+	}
+	defer migrator.Unlock(ctx)
+
+	group, err := migrator.Rollback(ctx)
+	if err != nil {
+		return err
+	}
+	if group.IsZero() {
+		logrus.Info("[Migrate] No migrations to roll back")
+		return nil
+	}
+
+	logrus.Infof("[Migrate] Rolled back %s", group)
+
+	return nil
+}
+
+// Prints every migration along with when, if at all, it was applied
+func status(ctx context.Context, migrator *bunmigrate.Migrator) error {
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range ms {
+		applied := "pending"
+		if m.IsApplied() {
+			applied = m.MigratedAt.Format(time.RFC3339)
+		}
+
+		fmt.Printf("%-40s %s\n", m.String(), applied)
+	}
+
+	fmt.Printf("\n%d applied, %d pending\n", len(ms.Applied()), len(ms.Unapplied()))
+
+	return nil
+}
+
+// Marks every pending migration, up to and including the given one, as applied without running it. Used to adopt a
+// database whose schema was created before migrations were tracked
+func baseline(ctx context.Context, migrator *bunmigrate.Migrator, name string) error {
+	if err := migrator.Lock(ctx); err != nil {
+		return err
+	}
+	defer migrator.Unlock(ctx)
+
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := ms.Unapplied()
+
+	last := -1
+	for i, m := range pending {
+		if m.String() == name || m.Name == name {
+			last = i
+			break
+		}
+	}
+	if last == -1 {
+		return ErrMigrateUnknownMigration
+	}
+
+	// Group them together so that they're rolled back together as well
+	group := &bunmigrate.MigrationGroup{
+		ID: ms.LastGroupID() + 1,
+	}
+	for i := range pending[:last+1] {
+		m := &pending[i]
+		m.GroupID = group.ID
+		if err := migrator.MarkApplied(ctx, m); err != nil {
+			return err
+		}
+
+		group.Migrations = append(group.Migrations, *m)
+	}
+
+	logrus.Infof("[Migrate] Marked %s as applied", group)
+
+	return nil
+}
+
+// Creates a new pair of up and down migration files
+//
+// NOTE: Migrations are embedded so the binary has to be rebuilt before they can be applied
+func create(name string) error {
+	if !regName.MatchString(name) {
+		return ErrMigrateInvalidName
+	}
+
+	version := time.Now().UTC().Format("20060102150405")
+	for _, suffix := range []string{"up", "down"} {
+		path := filepath.Join(directory, fmt.Sprintf("%s_%s.%s.sql", version, name, suffix))
+		if err := os.WriteFile(path, []byte(""), 0o644); err != nil {
+			return err
+		}
+
+		logrus.Infof("[Migrate] Created %s", path)
+	}
+
+	return nil
+}
@@ -30,19 +30,56 @@ func SetupHandlers(config config.Configuration, services WebServices) *chi.Mux {
 
 		Session: session,
 	}, router)
+	handlers.Challenge(handlers.ChallengeDependencies{
+		Config: config,
+
+		Service: services.Challenge(),
+
+		Session: session,
+	}, router)
+	handlers.DailyPuzzle(handlers.DailyPuzzleDependencies{
+		Service: services.DailyPuzzle(),
+
+		Session: session,
+	}, router)
 	handlers.Game(handlers.GameDependencies{
+		Config: config,
+
 		Puzzle:  services.Puzzle(),
 		Service: services.Game(),
 		User:    services.User(),
 
 		Session: session,
 	}, router)
+	handlers.Moderation(handlers.ModerationDependencies{
+		Service: services.Report(),
+
+		Session: session,
+	}, router)
+	handlers.OpenGraph(handlers.OpenGraphDependencies{
+		Config: config,
+
+		Game:   services.Game(),
+		Puzzle: services.Puzzle(),
+
+		Session: session,
+	}, router)
 	handlers.Puzzle(handlers.PuzzleDependencies{
+		Config: config,
+
+		Report:  services.Report(),
 		Service: services.Puzzle(),
 
 		Session: session,
 	}, router)
+	handlers.Tag(handlers.TagDependencies{
+		Puzzle:  services.Puzzle(),
+		Service: services.Tag(),
+
+		Session: session,
+	}, router)
 	handlers.User(handlers.UserDependencies{
+		Report:  services.Report(),
 		Service: services.User(),
 
 		Session: session,
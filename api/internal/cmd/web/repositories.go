@@ -2,16 +2,21 @@ package web
 
 import (
 	"github.com/RagOfJoes/puzzlely/internal/config"
+	"github.com/RagOfJoes/puzzlely/memory"
 	"github.com/RagOfJoes/puzzlely/postgres"
 	"github.com/RagOfJoes/puzzlely/repositories"
 	"github.com/sirupsen/logrus"
 )
 
 type WebRepositories struct {
-	game    repositories.Game
-	puzzle  repositories.Puzzle
-	session repositories.Session
-	user    repositories.User
+	challenge   repositories.Challenge
+	dailyPuzzle repositories.DailyPuzzle
+	game        repositories.Game
+	puzzle      repositories.Puzzle
+	report      repositories.Report
+	session     repositories.Session
+	tag         repositories.Tag
+	user        repositories.User
 }
 
 func NewWebRepositories(cfg config.Configuration) (WebRepositories, error) {
@@ -19,21 +24,50 @@ func NewWebRepositories(cfg config.Configuration) (WebRepositories, error) {
 
 	var repositories WebRepositories
 
+	if cfg.Database.Driver == config.DatabaseDriverMemory {
+		db := memory.New()
+
+		repositories = WebRepositories{
+			challenge:   memory.NewChallenge(db),
+			dailyPuzzle: memory.NewDailyPuzzle(db),
+			game:        memory.NewGame(db),
+			puzzle:      memory.NewPuzzle(db),
+			report:      memory.NewReport(db),
+			session:     memory.NewSession(db),
+			tag:         memory.NewTag(db),
+			user:        memory.NewUser(db),
+		}
+
+		return repositories, nil
+	}
+
 	db, err := postgres.Connect(cfg)
 	if err != nil {
 		return repositories, err
 	}
 
 	repositories = WebRepositories{
-		game:    postgres.NewGame(db),
-		puzzle:  postgres.NewPuzzle(db),
-		session: postgres.NewSession(db),
-		user:    postgres.NewUser(db),
+		challenge:   postgres.NewChallenge(db),
+		dailyPuzzle: postgres.NewDailyPuzzle(db),
+		game:        postgres.NewGame(db),
+		puzzle:      postgres.NewPuzzle(db),
+		report:      postgres.NewReport(db),
+		session:     postgres.NewSession(db),
+		tag:         postgres.NewTag(db),
+		user:        postgres.NewUser(db),
 	}
 
 	return repositories, nil
 }
 
+func (w *WebRepositories) Challenge() repositories.Challenge {
+	return w.challenge
+}
+
+func (w *WebRepositories) DailyPuzzle() repositories.DailyPuzzle {
+	return w.dailyPuzzle
+}
+
 func (w *WebRepositories) Game() repositories.Game {
 	return w.game
 }
@@ -42,10 +76,18 @@ func (w *WebRepositories) Puzzle() repositories.Puzzle {
 	return w.puzzle
 }
 
+func (w *WebRepositories) Report() repositories.Report {
+	return w.report
+}
+
 func (w *WebRepositories) Session() repositories.Session {
 	return w.session
 }
 
+func (w *WebRepositories) Tag() repositories.Tag {
+	return w.tag
+}
+
 func (w *WebRepositories) User() repositories.User {
 	return w.user
 }
@@ -7,11 +7,15 @@ import (
 )
 
 type WebServices struct {
-	game    services.Game
-	oauth   services.OAuth2Config
-	puzzle  services.Puzzle
-	session services.Session
-	user    services.User
+	challenge   services.Challenge
+	dailyPuzzle services.DailyPuzzle
+	game        services.Game
+	oauth       services.OAuth2Config
+	puzzle      services.Puzzle
+	report      services.Report
+	session     services.Session
+	tag         services.Tag
+	user        services.User
 }
 
 func NewWebServices(cfg config.Configuration, repositories WebRepositories) (WebServices, error) {
@@ -19,6 +23,17 @@ func NewWebServices(cfg config.Configuration, repositories WebRepositories) (Web
 	logrus.Info("[Web] Setting up services...")
 
 	return WebServices{
+		challenge: services.NewChallenge(services.ChallengeDependencies{
+			Game:       repositories.Game(),
+			Repository: repositories.Challenge(),
+		}),
+		dailyPuzzle: services.NewDailyPuzzle(services.DailyPuzzleDependencies{
+			Config: cfg,
+
+			Game:       repositories.Game(),
+			Puzzle:     repositories.Puzzle(),
+			Repository: repositories.DailyPuzzle(),
+		}),
 		game: services.NewGame(services.GameDependencies{
 			Repository: repositories.Game(),
 		}),
@@ -26,17 +41,36 @@ func NewWebServices(cfg config.Configuration, repositories WebRepositories) (Web
 			Config: cfg,
 		}),
 		puzzle: services.NewPuzzle(services.PuzzleDependencies{
+			Game:       repositories.Game(),
 			Repository: repositories.Puzzle(),
 		}),
+		report: services.NewReport(services.ReportDependencies{
+			Puzzle:     repositories.Puzzle(),
+			Repository: repositories.Report(),
+			User:       repositories.User(),
+		}),
 		session: services.NewSession(services.SessionDependencies{
 			Repository: repositories.Session(),
 		}),
+		tag: services.NewTag(services.TagDependencies{
+			Repository: repositories.Tag(),
+		}),
 		user: services.NewUser(services.UserDependencies{
+			Config: cfg,
+
 			Repository: repositories.User(),
 		}),
 	}, nil
 }
 
+func (w WebServices) Challenge() services.Challenge {
+	return w.challenge
+}
+
+func (w WebServices) DailyPuzzle() services.DailyPuzzle {
+	return w.dailyPuzzle
+}
+
 func (w WebServices) Game() services.Game {
 	return w.game
 }
@@ -49,10 +83,18 @@ func (w WebServices) Puzzle() services.Puzzle {
 	return w.puzzle
 }
 
+func (w WebServices) Report() services.Report {
+	return w.report
+}
+
 func (w WebServices) Session() services.Session {
 	return w.session
 }
 
+func (w WebServices) Tag() services.Tag {
+	return w.tag
+}
+
 func (w WebServices) User() services.User {
 	return w.user
 }
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// AccessControl defines the CORS options for the server. CORS is disabled, meaning only same-origin requests are allowed,
+// when `AllowedOrigins` is empty
+type AccessControl struct {
+	// AllowCredentials allows cookies and the Authorization header to be sent with cross-origin requests
+	//
+	// Default: false
+	AllowCredentials bool
+	// AllowHeaders are the headers that the client can use in a cross-origin request
+	//
+	// Default: Accept, Authorization, Content-Type
+	AllowHeaders []string
+	// AllowMethods are the methods that the client can use in a cross-origin request
+	//
+	// Default: GET, POST, PUT, DELETE, OPTIONS
+	AllowMethods []string
+	// AllowedOrigins are the origins that can make cross-origin requests. Can be `*` or contain a single wildcard
+	//
+	// Default: none
+	// Example: https://puzzlely.io, https://*.puzzlely.io
+	AllowedOrigins []string
+	// ExposeHeaders are the headers, other than the safelisted ones, that the client can read from the response
+	//
+	// Default: Retry-After
+	ExposeHeaders []string
+	// MaxAge is how long, in seconds, the result of a preflight request can be cached
+	//
+	// Default: 300
+	MaxAge int
+}
+
+func (a AccessControl) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.AllowCredentials, validation.By(func(value interface{}) error {
+			if value.(bool) && slices.Contains(a.AllowedOrigins, "*") {
+				return errors.New("cannot be enabled when every origin is allowed")
+			}
+
+			return nil
+		})),
+		validation.Field(&a.AllowHeaders, validation.Each(validation.Required)),
+		validation.Field(&a.AllowMethods, validation.Each(validation.Required, validation.In("GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"))),
+		validation.Field(&a.AllowedOrigins, validation.Each(validation.Required, validation.By(isOrigin))),
+		validation.Field(&a.ExposeHeaders, validation.Each(validation.Required)),
+		validation.Field(&a.MaxAge, validation.Min(0), validation.Max(86400)),
+	)
+}
+
+// Checks whether the value is `*` or an origin made up of only a scheme and host
+func isOrigin(value interface{}) error {
+	origin, _ := value.(string)
+	if origin == "*" {
+		return nil
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || (parsed.Path != "" && parsed.Path != "/") {
+		return errors.New("must be a valid origin")
+	}
+
+	return nil
+}
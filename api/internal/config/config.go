@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	// Embed the timezone database so that `Daily.Timezone` can be loaded on systems without one
+	_ "time/tzdata"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -40,8 +42,10 @@ type Configuration struct {
 
 	Logger Logger
 
+	Daily     Daily
 	Database  Database
 	Providers Providers
+	Roles     Roles
 
 	Server    Server
 	Session   Session
@@ -55,8 +59,10 @@ func (c Configuration) Validate() error {
 
 		validation.Field(&c.Logger, validation.Required),
 
+		validation.Field(&c.Daily, validation.Required),
 		validation.Field(&c.Database, validation.Required),
 		validation.Field(&c.Providers, validation.Required),
+		validation.Field(&c.Roles),
 
 		validation.Field(&c.Server, validation.Required),
 		validation.Field(&c.Session, validation.Required),
@@ -71,7 +77,24 @@ func SetDefaults(v *viper.Viper) {
 	v.SetDefault("LOGGER_LEVEL", int(logrus.InfoLevel))
 	v.SetDefault("LOGGER_REPORTCALLER", false)
 
+	// Daily
+	v.SetDefault("DAILY_TIMEZONE", "UTC")
+
 	// Server
+	v.SetDefault("SERVER_ACCESSCONTROL_ALLOWCREDENTIALS", false)
+	v.SetDefault("SERVER_ACCESSCONTROL_ALLOWHEADERS", []string{"Accept", "Authorization", "Content-Type"})
+	v.SetDefault("SERVER_ACCESSCONTROL_ALLOWMETHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
+	v.SetDefault("SERVER_ACCESSCONTROL_ALLOWEDORIGINS", []string{})
+	v.SetDefault("SERVER_ACCESSCONTROL_EXPOSEHEADERS", []string{"Retry-After"})
+	v.SetDefault("SERVER_ACCESSCONTROL_MAXAGE", 300)
+	v.SetDefault("SERVER_RATELIMITS_AUTH_REQUESTS", 10)
+	v.SetDefault("SERVER_RATELIMITS_AUTH_INTERVAL", "1m")
+	v.SetDefault("SERVER_RATELIMITS_GAMES_REQUESTS", 120)
+	v.SetDefault("SERVER_RATELIMITS_GAMES_INTERVAL", "1m")
+	v.SetDefault("SERVER_RATELIMITS_OG_REQUESTS", 60)
+	v.SetDefault("SERVER_RATELIMITS_OG_INTERVAL", "1m")
+	v.SetDefault("SERVER_RATELIMITS_PUZZLES_REQUESTS", 30)
+	v.SetDefault("SERVER_RATELIMITS_PUZZLES_INTERVAL", "1m")
 	v.SetDefault("SERVER_SECURITY_ISDEVELOPMENT", false)
 	v.SetDefault("SERVER_SECURITY_REFERRERPOLICY", "same-origin")
 	v.SetDefault("SERVER_SECURITY_HOSTSPROXYHEADERS", []string{"X-Forwarded-Hosts"})
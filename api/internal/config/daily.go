@@ -0,0 +1,32 @@
+package config
+
+import (
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Daily config
+type Daily struct {
+	// Timezone is the IANA timezone that decides when a new day, and with it a new daily puzzle, starts
+	//
+	// Default: UTC
+	Timezone string
+}
+
+func (d Daily) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Timezone, validation.Required, internal.IsTimezone),
+	)
+}
+
+// Location returns the location of `Timezone`. Falls back to UTC if `Timezone` is invalid
+func (d Daily) Location() *time.Location {
+	location, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
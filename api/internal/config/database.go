@@ -5,6 +5,14 @@ import (
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
+const (
+	// DatabaseDriverMemory keeps everything in memory. Useful for tests and local development since no database is needed
+	DatabaseDriverMemory = "memory"
+	DatabaseDriverMySQL  = "mysql"
+	// DatabaseDriverPostgres is the default driver
+	DatabaseDriverPostgres = "postgres"
+)
+
 type Database struct {
 	// Driver defines the type of database.
 	Driver   string
@@ -16,12 +24,15 @@ type Database struct {
 }
 
 func (d Database) Validate() error {
+	// Connection details aren't needed when everything is kept in memory
+	isRemote := d.Driver != DatabaseDriverMemory
+
 	return validation.ValidateStruct(&d,
-		validation.Field(&d.Driver, validation.Required, validation.In("mysql", "postgres")),
-		validation.Field(&d.Host, validation.Required),
-		validation.Field(&d.Name, validation.Required),
-		validation.Field(&d.Password, validation.Required),
-		validation.Field(&d.Port, validation.Required, is.Port),
-		validation.Field(&d.User, validation.Required),
+		validation.Field(&d.Driver, validation.Required, validation.In(DatabaseDriverMemory, DatabaseDriverMySQL, DatabaseDriverPostgres)),
+		validation.Field(&d.Host, validation.When(isRemote, validation.Required)),
+		validation.Field(&d.Name, validation.When(isRemote, validation.Required)),
+		validation.Field(&d.Password, validation.When(isRemote, validation.Required)),
+		validation.Field(&d.Port, validation.When(isRemote, validation.Required), is.Port),
+		validation.Field(&d.User, validation.When(isRemote, validation.Required)),
 	)
 }
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// RateLimit defines a token bucket that holds `Requests` tokens and is fully refilled every `Interval`
+type RateLimit struct {
+	// Requests is the number of requests that can be made within `Interval`. Setting this to 0 disables the limit
+	Requests int
+	// Interval is how long it takes for the bucket to be completely refilled
+	//
+	// Example: 1m
+	Interval time.Duration
+}
+
+func (r RateLimit) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Requests, validation.Min(0)),
+		validation.Field(&r.Interval, validation.When(r.Requests > 0, validation.Required, validation.Min(time.Second))),
+	)
+}
+
+// RateLimits defines the rate limit for each route group. Anonymous callers are limited by IP address and
+// authenticated callers are limited by their user id
+type RateLimits struct {
+	// Auth limits logging in since it calls out to the OAuth providers
+	Auth RateLimit
+	// Games limits saving games and making guesses
+	Games RateLimit
+	// OG limits rendering the share images of games and puzzles
+	OG RateLimit
+	// Puzzles limits creating, updating, liking, and deleting puzzles
+	Puzzles RateLimit
+}
+
+func (r RateLimits) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Auth),
+		validation.Field(&r.Games),
+		validation.Field(&r.OG),
+		validation.Field(&r.Puzzles),
+	)
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"github.com/RagOfJoes/puzzlely/internal"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Roles config
+type Roles struct {
+	// Admins are the ids of the users that are promoted to admins when they log in. This is how the first admins are
+	// created, after which they can grant roles to others
+	Admins []string
+}
+
+func (r Roles) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Admins, validation.Each(validation.By(internal.IsULID))),
+	)
+}
@@ -41,6 +41,10 @@ type Server struct {
 	// Middleware configurations
 	//
 
+	// AccessControl are the options that controls the CORS middleware.
+	AccessControl AccessControl
+	// RateLimits are the limits for each route group.
+	RateLimits RateLimits
 	// Security are the options that controls the security middleware.
 	Security secure.Options
 }
@@ -51,6 +55,9 @@ func (s Server) Validate() error {
 		validation.Field(&s.Host, validation.Required, validation.In(is.Host, ":")),
 		validation.Field(&s.Scheme, validation.Required, validation.In("http", "https")),
 		validation.Field(&s.URL, validation.Required, is.URL),
+
+		validation.Field(&s.AccessControl),
+		validation.Field(&s.RateLimits),
 	)
 }
 
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	goaway "github.com/TwiN/go-away"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Content policy
+//
+// User content, like block values and group descriptions, can be written in any script. Content is normalized to NFC
+// before it is validated and stored, lengths are counted in graphemes (user-perceived characters) rather than bytes or
+// code points, and, look-alike characters from other scripts are folded together when checking for duplicates
+
+// Zero width joiner is invisible but is used to join emoji sequences, like 👩‍🚀, so it is allowed in content
+const zeroWidthJoiner = '\u200d'
+
+// Characters from other scripts that look like Latin letters. These are the most commonly abused entries of the Unicode
+// confusables list (https://www.unicode.org/Public/security/latest/confusables.txt), after case folding
+var confusables = map[rune]rune{
+	// Cyrillic
+	'\u0430': 'a', '\u0441': 'c', '\u0501': 'd', '\u0435': 'e', '\u04bb': 'h', '\u0456': 'i', '\u0458': 'j',
+	'\u04cf': 'l', '\u043e': 'o', '\u0440': 'p', '\u051b': 'q', '\u0455': 's', '\u051d': 'w', '\u0445': 'x',
+	'\u0443': 'y',
+	// Greek
+	'\u03b1': 'a', '\u03b9': 'i', '\u03ba': 'k', '\u03bd': 'v', '\u03bf': 'o', '\u03c1': 'p', '\u03c5': 'u',
+	'\u03c7': 'x',
+	// Latin
+	'\u0131': 'i', '\u0251': 'a', '\u0261': 'g',
+}
+
+// Words, in addition to go-away's English defaults, that aren't allowed in each supported language. Words are written
+// the way go-away sanitizes input, so in lowercase and, for languages that have their accents removed, without accents
+var profanities = map[string][]string{
+	"de": {"arschloch", "ficken", "fotze", "hurensohn", "miststuck", "scheiße", "scheisse", "schlampe", "wichser"},
+	"es": {"cabron", "chingada", "chingar", "culero", "gilipollas", "hijueputa", "joder", "maricon", "mierda", "pendejo", "puta", "puto", "verga"},
+	"ja": {"きちがい", "キチガイ", "くそやろう", "クソ野郎", "くたばれ", "死ね", "ちんこ", "ちんぽ", "まんこ"},
+}
+
+// Words that contain one of a language's profanities but are fine to use
+var falsePositives = map[string][]string{
+	"es": {"computa", "computo", "diputa", "disputa", "disputo", "imputa", "imputo", "reputa"},
+}
+
+// Profanity detectors for each supported language. Each one also checks go-away's English defaults since English words
+// are commonly mixed into other languages
+var detectors = sync.OnceValue(func() map[string]*goaway.ProfanityDetector {
+	found := make(map[string]*goaway.ProfanityDetector, len(profanities))
+	for language, words := range profanities {
+		detector := goaway.NewProfanityDetector().WithCustomDictionary(
+			append(slices.Clone(goaway.DefaultProfanities), words...),
+			append(slices.Clone(goaway.DefaultFalsePositives), falsePositives[language]...),
+			goaway.DefaultFalseNegatives,
+		)
+		// Removing accents would strip the dakuten from kana, e.g. "ぽ" would become "ほ"
+		if language == "ja" {
+			detector = detector.WithSanitizeAccents(false)
+		}
+
+		found[language] = detector
+	}
+
+	return found
+})
+
+// Normalize returns the NFC form of the string with its surrounding whitespace removed
+func Normalize(value string) string {
+	return strings.TrimSpace(norm.NFC.String(value))
+}
+
+// Skeleton returns the form of the string that is used to detect confusable content. Strings that look alike, e.g.
+// "apple" and "аpple" written with a Cyrillic "а", or fullwidth "ａｐｐｌｅ", share the same skeleton. Accents are kept
+// since they change the meaning of words in many languages
+func Skeleton(value string) string {
+	value = strings.ToLower(norm.NFKC.String(value))
+
+	var sb strings.Builder
+	for _, r := range value {
+		// Drop invisible characters that could be used to make two strings differ
+		if unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r) || unicode.In(r, unicode.Cf, unicode.Variation_Selector) {
+			continue
+		}
+		if replacement, ok := confusables[r]; ok {
+			r = replacement
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return norm.NFC.String(strings.Join(strings.Fields(sb.String()), " "))
+}
+
+// IsPrintable checks if the string only contains printable characters, in any script, including emoji. Control
+// characters and invisible formatting characters, other than the zero width joiner, aren't allowed
+var IsPrintable = validation.NewStringRuleWithError(func(value string) bool {
+	for _, r := range value {
+		if r != zeroWidthJoiner && !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return true
+}, validation.NewError("validation_is_printable", "must contain printable characters only"))
+
+// IsCleanIn checks, like `IsClean`, that the string does not contain any dirty words in English or in the given
+// language. Languages without a dictionary of their own, like English, only use go-away's defaults. The string's
+// skeleton is also checked so that look-alike characters can't be used to get around the check
+func IsCleanIn(language string) validation.Rule {
+	detector, ok := detectors()[language]
+	if !ok {
+		return validation.NewStringRuleWithError(func(value string) bool {
+			return !goaway.IsProfane(value) && !goaway.IsProfane(Skeleton(value))
+		}, validation.NewError("validation_is_clean", "must not contain any dirty words"))
+	}
+
+	return validation.NewStringRuleWithError(func(value string) bool {
+		return !detector.IsProfane(value) && !detector.IsProfane(Skeleton(value))
+	}, validation.NewError("validation_is_clean", "must not contain any dirty words"))
+}
+
+// GraphemeLength checks that the string's length, in graphemes, is between min and max. Unlike `validation.Length`,
+// characters made up of more than one code point, like "é" written with a combining accent or most emoji, are only
+// counted once. Empty strings are skipped so that `validation.Required` can be used to require a value
+func GraphemeLength(min, max int) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		str, ok := value.(string)
+		if !ok || str == "" {
+			return nil
+		}
+
+		if length := uniseg.GraphemeClusterCount(str); length < min || length > max {
+			return fmt.Errorf("the length must be between %d and %d", min, max)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func TestSkeleton(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		skeleton string
+	}{
+		{name: "latin", value: "Apple", skeleton: "apple"},
+		{name: "cyrillic look-alike", value: "\u0430pple", skeleton: "apple"},
+		{name: "greek look-alike", value: "\u03bfrange", skeleton: "orange"},
+		{name: "fullwidth", value: "ａｐｐｌｅ", skeleton: "apple"},
+		{name: "zero width space", value: "ap\u200bple", skeleton: "apple"},
+		{name: "soft hyphen", value: "ap\u00adple", skeleton: "apple"},
+		{name: "variation selector", value: "apple\ufe0f", skeleton: "apple"},
+		{name: "repeated whitespace", value: "  green\t apple ", skeleton: "green apple"},
+		{name: "accents are kept", value: "Café", skeleton: "café"},
+		{name: "combining accents are composed", value: "Cafe\u0301", skeleton: "café"},
+		{name: "other scripts are kept", value: "りんご", skeleton: "りんご"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if skeleton := Skeleton(tt.value); skeleton != tt.skeleton {
+				t.Fatalf("expected %q, got %q", tt.skeleton, skeleton)
+			}
+		})
+	}
+}
+
+func TestIsCleanIn(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		value    string
+		clean    bool
+	}{
+		{name: "clean english", language: "en", value: "Apple", clean: true},
+		{name: "dirty english", language: "en", value: "shit", clean: false},
+		{name: "dirty english with look-alikes", language: "en", value: "\u0455hit", clean: false},
+		{name: "dirty english in another language", language: "es", value: "shit", clean: false},
+		{name: "dirty spanish", language: "es", value: "mierda", clean: false},
+		{name: "dirty spanish with look-alikes", language: "es", value: "mi\u0435rda", clean: false},
+		{name: "spanish false positive", language: "es", value: "disputa", clean: true},
+		{name: "spanish words are only checked in spanish", language: "en", value: "mierda", clean: true},
+		{name: "dirty german", language: "de", value: "Scheiße", clean: false},
+		{name: "dirty japanese", language: "ja", value: "死ね", clean: false},
+		{name: "japanese dakuten are kept", language: "ja", value: "ちんぽ", clean: false},
+		{name: "unsupported language", language: "fr", value: "Pomme", clean: true},
+		{name: "no language", language: "", value: "shit", clean: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.Validate(tt.value, IsCleanIn(tt.language))
+			if tt.clean && err != nil {
+				t.Fatalf("expected %q to be clean in %q, got %v", tt.value, tt.language, err)
+			}
+			if !tt.clean && err == nil {
+				t.Fatalf("expected %q to be dirty in %q", tt.value, tt.language)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ const (
 	ErrorCodeBadRequest       ErrorCode = "BadRequest"
 	ErrorCodeUnauthorized     ErrorCode = "Unauthorized"
 	ErrorCodeMethodNotAllowed ErrorCode = "MethodNotAllowed"
+	ErrorCodeTooManyRequests  ErrorCode = "TooManyRequests"
 )
 
 type Error struct {
@@ -68,6 +69,8 @@ func (e *Error) Render(w http.ResponseWriter, r *http.Request) error {
 		render.Status(r, http.StatusNotFound)
 	case ErrorCodeMethodNotAllowed:
 		render.Status(r, http.StatusMethodNotAllowed)
+	case ErrorCodeTooManyRequests:
+		render.Status(r, http.StatusTooManyRequests)
 	default:
 		render.Status(r, http.StatusInternalServerError)
 	}
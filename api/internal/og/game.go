@@ -0,0 +1,74 @@
+package og
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+)
+
+// Game renders the result card of a game. The card shows the game's attempts, where each block is colored by the group
+// it belongs to, so that neither the values nor the descriptions of the puzzle are revealed
+//
+// NOTE: `game.Puzzle` must be loaded with its groups and blocks
+func Game(w io.Writer, game domains.Game) error {
+	c, err := newCard()
+	if err != nil {
+		return err
+	}
+
+	// Map each block to the color of the group it belongs to
+	colors := make(map[string]color.Color, 0)
+	for i, group := range game.Puzzle.Groups {
+		for _, block := range group.Blocks {
+			colors[block.ID] = GroupColors[i%len(GroupColors)]
+		}
+	}
+
+	rows := make([][]color.Color, 0, len(game.Attempts))
+	for _, attempt := range game.Attempts {
+		row := make([]color.Color, 0, len(attempt))
+		for _, block := range attempt {
+			col, ok := colors[block]
+			if !ok {
+				col = tile
+			}
+
+			row = append(row, col)
+		}
+
+		rows = append(rows, row)
+	}
+	board := image.Rect(Width/2+padding/2, padding, Width-padding, Height-padding)
+	c.grid(board, rows)
+
+	maxWidth := board.Min.X - padding*2
+	if _, err := c.text(c.bold, 36, muted, padding, padding+36, maxWidth, "PUZZLELY"); err != nil {
+		return err
+	}
+
+	score := fmt.Sprintf("%d/%d", game.Score, len(game.Puzzle.Groups))
+	if _, err := c.text(c.bold, 128, foreground, padding, 300, maxWidth, score); err != nil {
+		return err
+	}
+	if _, err := c.text(c.regular, 44, foreground, padding, 370, maxWidth, "in "+plural(len(game.Attempts), "attempt", "attempts")); err != nil {
+		return err
+	}
+
+	difficulty, ok := difficultyColors[game.Puzzle.Difficulty]
+	if !ok {
+		difficulty = muted
+	}
+	x, err := c.text(c.bold, 32, difficulty, padding, Height-padding, maxWidth, strings.ToUpper(game.Puzzle.Difficulty))
+	if err != nil {
+		return err
+	}
+	if _, err := c.text(c.regular, 32, muted, x, Height-padding, maxWidth-(x-padding), " played by "+game.User.Username); err != nil {
+		return err
+	}
+
+	return c.encode(w)
+}
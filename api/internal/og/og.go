@@ -0,0 +1,180 @@
+// Package og renders the Open Graph cards that are shown as previews when links are shared on social platforms. Cards
+// are drawn in pure Go using the fonts bundled with golang.org/x/image so that nothing needs to be installed on the host
+package og
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Recommended dimensions of an Open Graph image
+const (
+	Width  = 1200
+	Height = 630
+)
+
+// Space between the edges of the card and its content
+const padding = 72
+
+// Palette
+var (
+	background = color.RGBA{R: 0x18, G: 0x18, B: 0x1b, A: 0xff}
+	foreground = color.RGBA{R: 0xfa, G: 0xfa, B: 0xfa, A: 0xff}
+	muted      = color.RGBA{R: 0xa1, G: 0xa1, B: 0xaa, A: 0xff}
+	tile       = color.RGBA{R: 0x3f, G: 0x3f, B: 0x46, A: 0xff}
+)
+
+// GroupColors defines the colors that each group is drawn with, in the order of the puzzle's groups. These mirror
+// `domains.GameShareColors`
+var GroupColors = []color.RGBA{
+	{R: 0xf9, G: 0xdf, B: 0x6d, A: 0xff},
+	{R: 0xa0, G: 0xc3, B: 0x5a, A: 0xff},
+	{R: 0x6f, G: 0x9b, B: 0xe8, A: 0xff},
+	{R: 0xba, G: 0x81, B: 0xc5, A: 0xff},
+	{R: 0xe0, G: 0x5d, B: 0x5d, A: 0xff},
+	{R: 0xf0, G: 0x9a, B: 0x4a, A: 0xff},
+	{R: 0x9b, G: 0x6b, B: 0x43, A: 0xff},
+	{R: 0xe4, G: 0xe4, B: 0xe7, A: 0xff},
+}
+
+// Colors for each of the puzzle difficulties
+var difficultyColors = map[string]color.RGBA{
+	"EASY":   {R: 0x4a, G: 0xde, B: 0x80, A: 0xff},
+	"MEDIUM": {R: 0xfa, G: 0xcc, B: 0x15, A: 0xff},
+	"HARD":   {R: 0xf8, G: 0x71, B: 0x71, A: 0xff},
+}
+
+// Fonts are parsed once and shared, but, faces aren't safe for concurrent use so they're created for every card
+var fonts = sync.OnceValues(func() ([2]*sfnt.Font, error) {
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return [2]*sfnt.Font{}, err
+	}
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return [2]*sfnt.Font{}, err
+	}
+
+	return [2]*sfnt.Font{bold, regular}, nil
+})
+
+// card defines the canvas that a single image is drawn on
+type card struct {
+	image *image.RGBA
+
+	bold    *sfnt.Font
+	regular *sfnt.Font
+}
+
+// Creates an empty card filled with the background color
+func newCard() (*card, error) {
+	parsed, err := fonts()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &card{
+		image: image.NewRGBA(image.Rect(0, 0, Width, Height)),
+
+		bold:    parsed[0],
+		regular: parsed[1],
+	}
+	c.fill(c.image.Bounds(), background)
+
+	return c, nil
+}
+
+// Fills the given rectangle with a solid color
+func (c *card) fill(rect image.Rectangle, col color.Color) {
+	draw.Draw(c.image, rect, image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+// Draws a single line of text with its baseline at the given point. Text that is wider than `maxWidth` is truncated
+// with an ellipsis. Returns the x coordinate at which the text ends
+func (c *card) text(f *sfnt.Font, size float64, col color.Color, x, y, maxWidth int, s string) (int, error) {
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return x, err
+	}
+	defer face.Close()
+
+	drawer := &font.Drawer{
+		Dst:  c.image,
+		Src:  image.NewUniform(col),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+
+	if drawer.MeasureString(s).Ceil() > maxWidth {
+		runes := []rune(s)
+		for len(runes) > 0 && drawer.MeasureString(string(runes)+"…").Ceil() > maxWidth {
+			runes = runes[:len(runes)-1]
+		}
+
+		s = string(runes) + "…"
+	}
+
+	drawer.DrawString(s)
+
+	return drawer.Dot.X.Ceil(), nil
+}
+
+// Draws a grid of square tiles, row by row, that fits inside the given rectangle. The grid is centered vertically
+// and aligned to the right of the rectangle. Rows that don't fit are left out
+func (c *card) grid(rect image.Rectangle, rows [][]color.Color) {
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if len(rows) == 0 || columns == 0 {
+		return
+	}
+
+	// Keep the tiles big enough to be told apart
+	cell := min(rect.Dy()/len(rows), rect.Dx()/columns, 120)
+	cell = max(cell, 6)
+	gap := max(cell/8, 1)
+
+	rows = rows[:min(len(rows), rect.Dy()/cell)]
+
+	top := rect.Min.Y + (rect.Dy()-len(rows)*cell+gap)/2
+	left := rect.Max.X - columns*cell + gap
+	for i, row := range rows {
+		for j, col := range row {
+			x := left + j*cell
+			y := top + i*cell
+
+			c.fill(image.Rect(x, y, x+cell-gap, y+cell-gap), col)
+		}
+	}
+}
+
+// Encodes the card as a PNG
+func (c *card) encode(w io.Writer) error {
+	return png.Encode(w, c.image)
+}
+
+// Formats a count along with the singular, or plural, form of a noun
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+
+	return fmt.Sprintf("%d %s", n, plural)
+}
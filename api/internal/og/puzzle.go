@@ -0,0 +1,55 @@
+package og
+
+import (
+	"image"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+)
+
+// Puzzle renders the preview card of a puzzle. The card shows the puzzle's difficulty, creator, and, likes along with
+// an empty board so that none of the puzzle's blocks are revealed
+func Puzzle(w io.Writer, puzzle domains.Puzzle) error {
+	c, err := newCard()
+	if err != nil {
+		return err
+	}
+
+	// Draw an empty board in the shape of the puzzle
+	rows := make([][]color.Color, 0, len(puzzle.Groups))
+	for _, group := range puzzle.Groups {
+		row := make([]color.Color, len(group.Blocks))
+		for i := range row {
+			row[i] = tile
+		}
+
+		rows = append(rows, row)
+	}
+	board := image.Rect(Width/2+padding/2, padding, Width-padding, Height-padding)
+	c.grid(board, rows)
+
+	maxWidth := board.Min.X - padding*2
+	if _, err := c.text(c.bold, 36, muted, padding, padding+36, maxWidth, "PUZZLELY"); err != nil {
+		return err
+	}
+
+	difficulty, ok := difficultyColors[puzzle.Difficulty]
+	if !ok {
+		difficulty = foreground
+	}
+	title := strings.ToUpper(puzzle.Difficulty[:1]) + strings.ToLower(puzzle.Difficulty[1:])
+	if _, err := c.text(c.bold, 104, difficulty, padding, 290, maxWidth, title); err != nil {
+		return err
+	}
+	if _, err := c.text(c.regular, 44, foreground, padding, 370, maxWidth, "by "+puzzle.CreatedBy.Username); err != nil {
+		return err
+	}
+
+	if _, err := c.text(c.regular, 36, muted, padding, Height-padding, maxWidth, plural(puzzle.NumOfLikes, "like", "likes")); err != nil {
+		return err
+	}
+
+	return c.encode(w)
+}
@@ -38,6 +38,14 @@ var IsUsername = validation.NewStringRuleWithError(func(value string) bool {
 	return regUsername.MatchString(value)
 }, validation.NewError("validation_is_username", "must start with a letter, end with a letter or number, and can include periods, hyphens, and underscores (but not consecutively)"))
 
+// IsTimezone checks if the string is a valid IANA timezone
+var IsTimezone = validation.NewStringRuleWithError(func(value string) bool {
+	// `Local` depends on the server so it isn't allowed
+	_, err := time.LoadLocation(value)
+
+	return err == nil && value != "Local"
+}, validation.NewError("validation_is_timezone", "must be a valid IANA timezone"))
+
 func IsULID(value interface{}) error {
 	switch value.(type) {
 	case string:
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Challenge = (*challenge)(nil)
+
+type challenge struct {
+	tracer trace.Tracer
+
+	db *DB
+}
+
+func NewChallenge(db *DB) repositories.Challenge {
+	logrus.Info("Created Challenge Memory Repository")
+
+	return &challenge{
+		tracer: telemetry.Tracer("memory.challenge"),
+
+		db: db,
+	}
+}
+
+func (c *challenge) Create(ctx context.Context, payload domains.Challenge) (*domains.Challenge, error) {
+	_, span := c.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	if payload.CreatedAt.IsZero() {
+		payload.CreatedAt = time.Now()
+	}
+	payload.CreatedAt = truncate(payload.CreatedAt)
+	payload.AcceptedAt = truncateNull(payload.AcceptedAt)
+	c.db.challenges[payload.ID] = c.strip(payload)
+
+	return c.load(payload), nil
+}
+
+func (c *challenge) Get(ctx context.Context, id string) (*domains.Challenge, error) {
+	_, span := c.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	c.db.mu.RLock()
+	defer c.db.mu.RUnlock()
+
+	challenge, ok := c.db.challenges[id]
+	if !ok {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	return c.load(challenge), nil
+}
+
+func (c *challenge) GetForUser(ctx context.Context, id string, opts domains.ChallengeCursorPaginationOpts) ([]domains.Challenge, error) {
+	_, span := c.tracer.Start(ctx, "GetForUser", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor time.Time
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = decoded
+	}
+
+	c.db.mu.RLock()
+	defer c.db.mu.RUnlock()
+
+	challenges := make([]domains.Challenge, 0)
+	for _, challenge := range c.db.challenges {
+		if !challenge.IsParticipant(id) {
+			continue
+		}
+		if !cursor.IsZero() && challenge.CreatedAt.After(cursor) {
+			continue
+		}
+
+		challenges = append(challenges, *c.load(challenge))
+	}
+
+	sort.Slice(challenges, func(i, j int) bool {
+		return challenges[i].CreatedAt.After(challenges[j].CreatedAt)
+	})
+
+	return limit(challenges, opts.Limit+1), nil
+}
+
+func (c *challenge) Accept(ctx context.Context, payload domains.Challenge) (*domains.Challenge, error) {
+	_, span := c.tracer.Start(ctx, "Accept", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	stored, ok := c.db.challenges[payload.ID]
+	if !ok {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+	if stored.IsAccepted() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrChallengeAccepted)
+
+		return nil, repositories.ErrChallengeAccepted
+	}
+
+	stored.AcceptedAt = truncateNull(payload.AcceptedAt)
+	stored.OpponentID = payload.OpponentID
+	c.db.challenges[stored.ID] = stored
+
+	return c.load(stored), nil
+}
+
+// Removes the relations from a challenge before it's stored
+func (c *challenge) strip(challenge domains.Challenge) domains.Challenge {
+	challenge.User = domains.User{}
+	challenge.Opponent = nil
+	challenge.Result = nil
+	challenge.OpponentResult = nil
+	challenge.WinnerID = ""
+
+	return challenge
+}
+
+// Loads the relations of a stored challenge
+//
+// NOTE: Expects the caller to hold the lock
+func (c *challenge) load(challenge domains.Challenge) *domains.Challenge {
+	challenge.User = c.db.users[challenge.UserID]
+	if opponent, ok := c.db.users[challenge.OpponentID]; ok {
+		challenge.Opponent = &opponent
+	}
+
+	return &challenge
+}
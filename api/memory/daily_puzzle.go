@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.DailyPuzzle = (*dailyPuzzle)(nil)
+
+type dailyPuzzle struct {
+	tracer trace.Tracer
+
+	db *DB
+}
+
+func NewDailyPuzzle(db *DB) repositories.DailyPuzzle {
+	logrus.Info("Created Daily Puzzle Memory Repository")
+
+	return &dailyPuzzle{
+		tracer: telemetry.Tracer("memory.daily_puzzle"),
+
+		db: db,
+	}
+}
+
+func (d *dailyPuzzle) Create(ctx context.Context, payload domains.DailyPuzzle) (*domains.DailyPuzzle, error) {
+	_, span := d.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	d.db.mu.Lock()
+	defer d.db.mu.Unlock()
+
+	date := payload.Date.Format(domains.DailyPuzzleDateLayout)
+	isAvailable := true
+	for key, dailyPuzzle := range d.db.dailyPuzzles {
+		if key == date || dailyPuzzle.PuzzleID == payload.PuzzleID {
+			isAvailable = false
+		}
+	}
+	if !isAvailable {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrDailyPuzzleNotAvailable)
+
+		return nil, repositories.ErrDailyPuzzleNotAvailable
+	}
+
+	// Like the date column, only keep the date
+	payload.Date, _ = time.Parse(domains.DailyPuzzleDateLayout, date)
+	if payload.CreatedAt.IsZero() {
+		payload.CreatedAt = time.Now()
+	}
+	payload.CreatedAt = truncate(payload.CreatedAt)
+	payload.Puzzle = domains.Puzzle{}
+
+	d.db.dailyPuzzles[date] = payload
+
+	return &payload, nil
+}
+
+func (d *dailyPuzzle) Delete(ctx context.Context, date string) error {
+	_, span := d.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	d.db.mu.Lock()
+	defer d.db.mu.Unlock()
+
+	delete(d.db.dailyPuzzles, date)
+
+	return nil
+}
+
+func (d *dailyPuzzle) Get(ctx context.Context, date string) (*domains.DailyPuzzle, error) {
+	_, span := d.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	d.db.mu.RLock()
+	defer d.db.mu.RUnlock()
+
+	dailyPuzzle, ok := d.db.dailyPuzzles[date]
+	if !ok {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	return &dailyPuzzle, nil
+}
+
+func (d *dailyPuzzle) GetLatest(ctx context.Context) (*domains.DailyPuzzle, error) {
+	_, span := d.tracer.Start(ctx, "GetLatest", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	d.db.mu.RLock()
+	defer d.db.mu.RUnlock()
+
+	var latest *domains.DailyPuzzle
+	for _, dailyPuzzle := range d.db.dailyPuzzles {
+		if latest == nil || dailyPuzzle.Date.After(latest.Date) {
+			latest = &dailyPuzzle
+		}
+	}
+
+	return latest, nil
+}
+
+func (d *dailyPuzzle) GetPast(ctx context.Context, date string, opts domains.PuzzleCursorPaginationOpts) ([]domains.DailyPuzzle, error) {
+	_, span := d.tracer.Start(ctx, "GetPast", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor string
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := opts.Cursor.Decode()
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = decoded
+	}
+
+	d.db.mu.RLock()
+	defer d.db.mu.RUnlock()
+
+	// Dates are formatted as YYYY-MM-DD so they can be compared as strings
+	dailyPuzzles := make([]domains.DailyPuzzle, 0)
+	for key, dailyPuzzle := range d.db.dailyPuzzles {
+		if key >= date || (cursor != "" && key > cursor) {
+			continue
+		}
+		if puzzle, ok := d.db.puzzles[dailyPuzzle.PuzzleID]; !ok || !puzzle.DeletedAt.IsZero() || !puzzle.HiddenAt.IsZero() {
+			continue
+		}
+
+		dailyPuzzles = append(dailyPuzzles, dailyPuzzle)
+	}
+
+	sort.Slice(dailyPuzzles, func(i, j int) bool {
+		return dailyPuzzles[i].Date.After(dailyPuzzles[j].Date)
+	})
+
+	return limit(dailyPuzzles, opts.Limit+1), nil
+}
+
+func (d *dailyPuzzle) GetUpcoming(ctx context.Context, date string, n int) ([]domains.DailyPuzzle, error) {
+	_, span := d.tracer.Start(ctx, "GetUpcoming", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	d.db.mu.RLock()
+	defer d.db.mu.RUnlock()
+
+	dailyPuzzles := make([]domains.DailyPuzzle, 0)
+	for key, dailyPuzzle := range d.db.dailyPuzzles {
+		if key < date {
+			continue
+		}
+
+		dailyPuzzles = append(dailyPuzzles, dailyPuzzle)
+	}
+
+	sort.Slice(dailyPuzzles, func(i, j int) bool {
+		return dailyPuzzles[i].Date.Before(dailyPuzzles[j].Date)
+	})
+
+	return limit(dailyPuzzles, n), nil
+}
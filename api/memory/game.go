@@ -0,0 +1,350 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Game = (*game)(nil)
+
+type game struct {
+	tracer trace.Tracer
+
+	db *DB
+}
+
+func NewGame(db *DB) repositories.Game {
+	logrus.Info("Created Game Memory Repository")
+
+	return &game{
+		tracer: telemetry.Tracer("memory.game"),
+
+		db: db,
+	}
+}
+
+func (g *game) Get(ctx context.Context, id string) (*domains.Game, error) {
+	ctx, span := g.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	stored, ok := g.db.games[id]
+	if !ok {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	game := copyGame(stored)
+	// Like the join in Postgres, a deleted puzzle is left empty rather than hiding the game
+	if puzzle, err := g.db.puzzle(ctx, game.PuzzleID, false); err == nil {
+		game.Puzzle = puzzle
+	}
+	game.User = g.db.users[game.UserID]
+
+	return &game, nil
+}
+
+func (g *game) GetCorrect(ctx context.Context, ids []string) (map[string][]string, error) {
+	_, span := g.tracer.Start(ctx, "GetCorrect", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	correct := make(map[string][]string, 0)
+
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() {
+		return correct, nil
+	}
+
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	for _, game := range g.db.games {
+		if game.UserID != session.UserID.String || len(game.Correct) == 0 || !slices.Contains(ids, game.PuzzleID) {
+			continue
+		}
+
+		correct[game.PuzzleID] = slices.Clone(game.Correct)
+	}
+
+	return correct, nil
+}
+
+func (g *game) GetLeaderboard(ctx context.Context, id string, opts domains.LeaderboardOpts) ([]domains.LeaderboardEntry, error) {
+	_, span := g.tracer.Start(ctx, "GetLeaderboard", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor *domains.LeaderboardEntry
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := opts.DecodeCursor()
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = &decoded
+	}
+
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	entries := make([]domains.LeaderboardEntry, 0)
+	for _, entry := range g.db.leaderboard(id) {
+		if cursor != nil && isLeaderboardEntryBefore(entry, *cursor) {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return limit(entries, opts.Limit+1), nil
+}
+
+func (g *game) GetLeaderboardEntry(ctx context.Context, id string, userID string) (*domains.LeaderboardEntry, error) {
+	_, span := g.tracer.Start(ctx, "GetLeaderboardEntry", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	for _, entry := range g.db.leaderboard(id) {
+		if entry.UserID == userID {
+			return &entry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (g *game) GetLeaderboardEntries(ctx context.Context, ids []string, userIDs []string) ([]domains.LeaderboardEntry, error) {
+	_, span := g.tracer.Start(ctx, "GetLeaderboardEntries", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	entries := make([]domains.LeaderboardEntry, 0)
+	for _, id := range ids {
+		for _, entry := range g.db.leaderboard(id) {
+			if slices.Contains(userIDs, entry.UserID) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func (g *game) GetWithPuzzleID(ctx context.Context, id string) (*domains.Game, error) {
+	ctx, span := g.tracer.Start(ctx, "GetWithPuzzleID", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	// Games only belong to authenticated users
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	for _, stored := range g.db.games {
+		if stored.PuzzleID != id || stored.UserID != userID {
+			continue
+		}
+
+		game := copyGame(stored)
+		// Like the join in Postgres, a deleted puzzle is left empty rather than hiding the game
+		if puzzle, err := g.db.puzzle(ctx, game.PuzzleID, false); err == nil {
+			game.Puzzle = puzzle
+		}
+		// Show the puzzle as it was when the game was started
+		if version, ok := g.db.puzzleVersion(game.PuzzleID, game.PuzzleVersion); ok {
+			version.Pin(&game.Puzzle)
+		}
+		game.User = g.db.users[game.UserID]
+
+		return &game, nil
+	}
+
+	span.SetStatus(codes.Error, "")
+	span.RecordError(sql.ErrNoRows)
+
+	return nil, sql.ErrNoRows
+}
+
+func (g *game) GetHistory(ctx context.Context, id string, opts domains.GameCursorPaginationOpts) ([]domains.GameSummary, error) {
+	ctx, span := g.tracer.Start(ctx, "GetHistory", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor time.Time
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = decoded
+	}
+
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	games := make([]domains.GameSummary, 0)
+	for _, game := range g.db.games {
+		if game.UserID != id {
+			continue
+		}
+		if !cursor.IsZero() && game.CreatedAt.After(cursor) {
+			continue
+		}
+
+		summary := domains.GameSummary{
+			ID:       game.ID,
+			Score:    game.Score,
+			Attempts: int16(game.WrongAttempts()),
+			Hints:    int16(len(game.Hints)),
+
+			CreatedAt:   game.CreatedAt,
+			CompletedAt: game.CompletedAt,
+
+			PuzzleID:      game.PuzzleID,
+			PuzzleVersion: game.PuzzleVersion,
+			UserID: sql.NullString{
+				String: game.UserID,
+				Valid:  true,
+			},
+		}
+
+		// Include puzzles that have since been deleted so that the game can still be shown in the user's history
+		if puzzle, ok := g.db.puzzles[game.PuzzleID]; ok {
+			summary.Puzzle = g.db.puzzleSummary(ctx, puzzle)
+		}
+		// Show the puzzle as it was when the game was played
+		if version, ok := g.db.puzzleVersion(game.PuzzleID, game.PuzzleVersion); ok {
+			summary.Puzzle.Difficulty = version.Difficulty
+		}
+		if user, ok := g.db.users[game.UserID]; ok {
+			summary.User = &user
+		}
+
+		games = append(games, summary)
+	}
+
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].CreatedAt.After(games[j].CreatedAt)
+	})
+
+	return limit(games, opts.Limit+1), nil
+}
+
+func (g *game) Save(ctx context.Context, payload domains.Game) (*domains.Game, error) {
+	_, span := g.tracer.Start(ctx, "Save", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	g.db.mu.Lock()
+	defer g.db.mu.Unlock()
+
+	// A user can only have one game per puzzle so update the existing one, if any
+	var game domains.Game
+	for _, stored := range g.db.games {
+		if stored.PuzzleID == payload.PuzzleID && stored.UserID == payload.UserID {
+			game = stored
+			break
+		}
+	}
+
+	// Only update the game if it hasn't been saved since the caller loaded it
+	if game.ID != "" && game.Revision != payload.Revision {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrGameOutdated)
+
+		return nil, repositories.ErrGameOutdated
+	}
+
+	// Streaks should only be updated the first time that a game is completed
+	wasCompleted := !game.CompletedAt.IsZero()
+	if game.ID == "" {
+		// Pin new games to the current version of the puzzle. Existing games keep the version that they were started on.
+		// Like in Postgres, games that are completed in the same save that creates them are created when they're
+		// completed so that they're left untimed
+		game = domains.Game{
+			ID:        ulid.Make().String(),
+			CreatedAt: payload.CreatedAt,
+
+			PuzzleID:      payload.PuzzleID,
+			PuzzleVersion: g.db.puzzles[payload.PuzzleID].Version,
+			UserID:        payload.UserID,
+		}
+		if !payload.CompletedAt.IsZero() {
+			game.CreatedAt = payload.CompletedAt.Time
+		}
+		if game.CreatedAt.IsZero() {
+			game.CreatedAt = time.Now()
+		}
+		game.CreatedAt = truncate(game.CreatedAt)
+	}
+
+	game.Score = payload.Score
+	game.Revision += 1
+	game.CompletedAt = truncateNull(payload.CompletedAt)
+	game.Attempts = payload.Attempts
+	game.Correct = payload.Correct
+	game.Hints = make([]domains.GameHint, 0, len(payload.Hints))
+	for i, hint := range payload.Hints {
+		hint.ID = ulid.Make().String()
+		hint.Order = i
+		hint.GameID = game.ID
+
+		game.Hints = append(game.Hints, hint)
+	}
+
+	g.db.games[game.ID] = copyGame(game)
+
+	if !wasCompleted && !game.CompletedAt.IsZero() {
+		g.recordStreak(game)
+	}
+
+	game.Puzzle = payload.Puzzle
+	game.User = payload.User
+
+	return &game, nil
+}
+
+// Updates the streaks of the game's user with the day, in the user's timezone, that the game was completed on
+//
+// NOTE: The caller must hold the write lock
+func (g *game) recordStreak(game domains.Game) {
+	streak, ok := g.db.streaks[game.UserID]
+	if !ok {
+		streak = domains.NewUserStreak(game.UserID)
+	}
+
+	puzzle := g.db.puzzles[game.PuzzleID]
+	streak.Record(domains.StreakDay(game.CompletedAt.Time, g.db.users[game.UserID].Location()), len(game.Correct) == len(puzzle.Groups))
+	streak.UpdatedAt = truncate(streak.UpdatedAt)
+
+	g.db.streaks[game.UserID] = streak
+}
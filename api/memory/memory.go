@@ -0,0 +1,364 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// Layout that the services use to encode timestamps into cursors
+	cursorLayout = "2006-01-02 15:04:05.000000"
+)
+
+// DB is an in-memory store that is shared between all of the memory repositories. It mirrors the tables in the
+// `migrations` folder and is only meant to be used for tests and local development
+type DB struct {
+	mu sync.RWMutex
+
+	// Keyed by the challenge's id. Relations are not stored
+	challenges  map[string]domains.Challenge
+	connections map[string]domains.Connection
+	// Keyed by the date formatted with `domains.DailyPuzzleDateLayout`
+	dailyPuzzles map[string]domains.DailyPuzzle
+	// Keyed by the game's id. Relations are not stored
+	games map[string]domains.Game
+	// Keyed by the puzzle's id followed by the user's id
+	likes map[string]domains.PuzzleLike
+	// Keyed by the puzzle's id. Groups and blocks are stored but `CreatedBy` and `Tags` are not
+	puzzles map[string]domains.Puzzle
+	// Keyed by the puzzle's id. Holds the slugs of the puzzle's tags
+	puzzleTags map[string][]string
+	// Keyed by the puzzle's id. Each puzzle's versions are stored from oldest to newest
+	puzzleVersions map[string][]domains.PuzzleVersion
+	// Keyed by the report's id. `ReportedUser` is not stored
+	reports map[string]domains.Report
+	// Keyed by the session's id. `User` is not stored
+	sessions map[string]domains.Session
+	// Keyed by the user's id
+	streaks map[string]domains.UserStreak
+	// Keyed by the tag's slug. `NumOfPuzzles` is not stored
+	tags  map[string]domains.Tag
+	users map[string]domains.User
+}
+
+// New creates an empty in-memory store
+func New() *DB {
+	logrus.WithFields(logrus.Fields{
+		"driver": "memory",
+	}).Info("Successfully created in-memory store")
+
+	return &DB{
+		challenges:     make(map[string]domains.Challenge),
+		connections:    make(map[string]domains.Connection),
+		dailyPuzzles:   make(map[string]domains.DailyPuzzle),
+		games:          make(map[string]domains.Game),
+		likes:          make(map[string]domains.PuzzleLike),
+		puzzles:        make(map[string]domains.Puzzle),
+		puzzleTags:     make(map[string][]string),
+		puzzleVersions: make(map[string][]domains.PuzzleVersion),
+		reports:        make(map[string]domains.Report),
+		sessions:       make(map[string]domains.Session),
+		streaks:        make(map[string]domains.UserStreak),
+		tags:           make(map[string]domains.Tag),
+		users:          make(map[string]domains.User),
+	}
+}
+
+// Postgres only stores timestamps up to the microsecond so do the same to keep cursors consistent
+func truncate(t time.Time) time.Time {
+	return t.Truncate(time.Microsecond)
+}
+
+func truncateNull(t bun.NullTime) bun.NullTime {
+	if t.IsZero() {
+		return t
+	}
+
+	return bun.NullTime{Time: truncate(t.Time)}
+}
+
+// Decodes the cursor into the timestamp it was created from
+func decodeCursor(cursor domains.Cursor) (time.Time, error) {
+	decoded, err := cursor.Decode()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parseCursor(decoded)
+}
+
+func parseCursor(cursor string) (time.Time, error) {
+	parsed, err := time.ParseInLocation(cursorLayout, cursor, time.Local)
+	if err != nil {
+		return time.Time{}, domains.ErrCursorInvalid
+	}
+
+	return parsed, nil
+}
+
+func likeKey(puzzleID, userID string) string {
+	return puzzleID + userID
+}
+
+// Returns the id of the user that is authenticated in the context, if any
+func sessionUserID(ctx context.Context) (string, bool) {
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() {
+		return "", false
+	}
+
+	return session.UserID.String, true
+}
+
+func copyGame(game domains.Game) domains.Game {
+	attempts := make([][]string, 0, len(game.Attempts))
+	for _, attempt := range game.Attempts {
+		attempts = append(attempts, slices.Clone(attempt))
+	}
+	game.Attempts = attempts
+	game.Correct = append(make([]string, 0, len(game.Correct)), game.Correct...)
+	game.Hints = append(make([]domains.GameHint, 0, len(game.Hints)), game.Hints...)
+
+	return game
+}
+
+func copyPuzzle(puzzle domains.Puzzle) domains.Puzzle {
+	groups := make([]domains.PuzzleGroup, 0, len(puzzle.Groups))
+	for _, group := range puzzle.Groups {
+		group.Blocks = slices.Clone(group.Blocks)
+		groups = append(groups, group)
+	}
+	puzzle.Groups = groups
+	puzzle.Tags = slices.Clone(puzzle.Tags)
+
+	return puzzle
+}
+
+// NOTE: The following methods expect the caller to hold the lock
+
+func (d *DB) numOfLikes(puzzleID string) int {
+	count := 0
+	for _, like := range d.likes {
+		if like.PuzzleID == puzzleID && like.Active {
+			count += 1
+		}
+	}
+
+	return count
+}
+
+// Returns when the authenticated user, if any, liked the puzzle
+func (d *DB) likedAt(ctx context.Context, puzzleID string) bun.NullTime {
+	userID, ok := sessionUserID(ctx)
+	if !ok {
+		return bun.NullTime{}
+	}
+
+	like, ok := d.likes[likeKey(puzzleID, userID)]
+	if !ok || !like.Active {
+		return bun.NullTime{}
+	}
+
+	return bun.NullTime{Time: like.UpdatedAt}
+}
+
+// Returns the tag with the given slug along with the number of listed puzzles that use it
+func (d *DB) tag(slug string) (domains.Tag, bool) {
+	tag, ok := d.tags[slug]
+	if !ok {
+		return domains.Tag{}, false
+	}
+
+	for id, slugs := range d.puzzleTags {
+		puzzle := d.puzzles[id]
+		if puzzle.DeletedAt.IsZero() && puzzle.HiddenAt.IsZero() && slices.Contains(slugs, slug) {
+			tag.NumOfPuzzles += 1
+		}
+	}
+
+	return tag, true
+}
+
+// Returns the tags of the puzzle ordered by their slug
+func (d *DB) puzzleTagsOf(puzzleID string) []domains.Tag {
+	slugs := d.puzzleTags[puzzleID]
+	if len(slugs) == 0 {
+		return nil
+	}
+
+	tags := make([]domains.Tag, 0, len(slugs))
+	for _, slug := range slices.Sorted(slices.Values(slugs)) {
+		if tag, ok := d.tag(slug); ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// Links the puzzle to the given tags, in place of the tags that it was linked to before, and creates the tags that
+// don't exist yet
+func (d *DB) saveTags(puzzleID string, tags []domains.Tag) []domains.Tag {
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if _, ok := d.tags[tag.Slug]; !ok {
+			tag.NumOfPuzzles = 0
+			tag.CreatedAt = truncate(time.Now())
+			d.tags[tag.Slug] = tag
+		}
+
+		slugs = append(slugs, tag.Slug)
+	}
+	d.puzzleTags[puzzleID] = slugs
+
+	return d.puzzleTagsOf(puzzleID)
+}
+
+// Checks whether the user has completed a game for the puzzle
+func (d *DB) hasCompleted(puzzleID, userID string) bool {
+	for _, game := range d.games {
+		if game.PuzzleID == puzzleID && game.UserID == userID && !game.CompletedAt.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns every completed game for the puzzle ranked by score, then by number of attempts, then by solve time
+func (d *DB) leaderboard(puzzleID string) []domains.LeaderboardEntry {
+	entries := make([]domains.LeaderboardEntry, 0)
+	for _, game := range d.games {
+		if game.PuzzleID != puzzleID || game.CompletedAt.IsZero() || game.UserID == "" {
+			continue
+		}
+
+		entries = append(entries, domains.LeaderboardEntry{
+			ID: game.ID,
+
+			Score:     game.Score,
+			Attempts:  int16(len(game.Attempts)),
+			Hints:     int16(len(game.Hints)),
+			Timed:     game.CompletedAt.After(game.CreatedAt),
+			SolveTime: game.CompletedAt.Sub(game.CreatedAt).Milliseconds(),
+
+			CreatedAt:   game.CreatedAt,
+			CompletedAt: game.CompletedAt.Time,
+
+			PuzzleVersion: game.PuzzleVersion,
+
+			PuzzleID: game.PuzzleID,
+			UserID:   game.UserID,
+			User:     d.users[game.UserID],
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return isLeaderboardEntryBefore(entries[i], entries[j])
+	})
+
+	for i := range entries {
+		// Ties share the same rank like `RANK()` in Postgres
+		if i > 0 && !isLeaderboardEntryTied(entries[i-1], entries[i]) {
+			entries[i].Rank = i + 1
+		} else if i > 0 {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = 1
+		}
+	}
+
+	return entries
+}
+
+// Returns a copy of the puzzle with its relations and computed columns loaded. Soft deleted puzzles are only returned
+// when `withDeleted` is set
+func (d *DB) puzzle(ctx context.Context, id string, withDeleted bool) (domains.Puzzle, error) {
+	stored, ok := d.puzzles[id]
+	if !ok || (!withDeleted && !stored.DeletedAt.IsZero()) {
+		return domains.Puzzle{}, sql.ErrNoRows
+	}
+
+	puzzle := copyPuzzle(stored)
+	puzzle.CreatedBy = d.users[puzzle.UserID]
+	puzzle.LikedAt = d.likedAt(ctx, puzzle.ID)
+	puzzle.NumOfLikes = d.numOfLikes(puzzle.ID)
+	puzzle.Tags = d.puzzleTagsOf(puzzle.ID)
+
+	return puzzle, nil
+}
+
+// Returns the summary of a stored puzzle
+func (d *DB) puzzleSummary(ctx context.Context, puzzle domains.Puzzle) domains.PuzzleSummary {
+	return domains.PuzzleSummary{
+		ID:          puzzle.ID,
+		Difficulty:  puzzle.Difficulty,
+		MaxAttempts: puzzle.MaxAttempts,
+		GroupCount:  puzzle.GroupCount,
+		GroupSize:   puzzle.GroupSize,
+		Language:    puzzle.Language,
+
+		Tags: d.puzzleTagsOf(puzzle.ID),
+
+		MeLikedAt:  d.likedAt(ctx, puzzle.ID),
+		NumOfLikes: d.numOfLikes(puzzle.ID),
+
+		CreatedAt: puzzle.CreatedAt,
+		UpdatedAt: puzzle.UpdatedAt,
+		DeletedAt: puzzle.DeletedAt,
+
+		UserID:    puzzle.UserID,
+		CreatedBy: d.users[puzzle.UserID],
+	}
+}
+
+// Returns, at most, the first n items
+func limit[T any](items []T, n int) []T {
+	if n < 0 || len(items) <= n {
+		return items
+	}
+
+	return items[:n]
+}
+
+// Checks whether entry a is ordered before entry b on a leaderboard. The id breaks ties so that the order is stable
+func isLeaderboardEntryBefore(a, b domains.LeaderboardEntry) bool {
+	if !isLeaderboardEntryTied(a, b) {
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Attempts != b.Attempts {
+			return a.Attempts < b.Attempts
+		}
+		if a.Timed != b.Timed {
+			return a.Timed
+		}
+
+		return a.SolveTime < b.SolveTime
+	}
+
+	return a.ID < b.ID
+}
+
+// Checks whether entries a and b share the same rank on a leaderboard
+func isLeaderboardEntryTied(a, b domains.LeaderboardEntry) bool {
+	return a.Score == b.Score && a.Attempts == b.Attempts && a.Timed == b.Timed && a.SolveTime == b.SolveTime
+}
+
+// Returns the given version of a puzzle, if it exists
+func (d *DB) puzzleVersion(puzzleID string, version int) (domains.PuzzleVersion, bool) {
+	versions := d.puzzleVersions[puzzleID]
+	if version < 1 || version > len(versions) {
+		return domains.PuzzleVersion{}, false
+	}
+
+	return versions[version-1], true
+}
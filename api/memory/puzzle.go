@@ -0,0 +1,572 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Puzzle = (*puzzle)(nil)
+
+type puzzle struct {
+	tracer trace.Tracer
+
+	db *DB
+}
+
+func NewPuzzle(db *DB) repositories.Puzzle {
+	logrus.Info("Created Puzzle Memory Repository")
+
+	return &puzzle{
+		tracer: telemetry.Tracer("memory.puzzle"),
+
+		db: db,
+	}
+}
+
+func (p *puzzle) Create(ctx context.Context, payload domains.Puzzle) (*domains.Puzzle, error) {
+	_, span := p.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	if payload.CreatedAt.IsZero() {
+		payload.CreatedAt = time.Now()
+	}
+	payload.CreatedAt = truncate(payload.CreatedAt)
+	payload.UpdatedAt = truncateNull(payload.UpdatedAt)
+
+	stored := copyPuzzle(payload)
+	stored.CreatedBy = domains.User{}
+	stored.Tags = nil
+	p.db.puzzles[stored.ID] = stored
+
+	version := domains.NewPuzzleVersion(stored)
+	version.CreatedAt = stored.CreatedAt
+	p.db.puzzleVersions[stored.ID] = []domains.PuzzleVersion{version}
+
+	puzzle := copyPuzzle(payload)
+	puzzle.Tags = p.db.saveTags(stored.ID, payload.Tags)
+	return &puzzle, nil
+}
+
+func (p *puzzle) Delete(ctx context.Context, id string) error {
+	_, span := p.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	puzzle, ok := p.db.puzzles[id]
+	if !ok || !puzzle.DeletedAt.IsZero() {
+		return nil
+	}
+
+	puzzle.DeletedAt = bun.NullTime{Time: truncate(time.Now())}
+	p.db.puzzles[id] = puzzle
+
+	return nil
+}
+
+func (p *puzzle) Get(ctx context.Context, id string) (*domains.Puzzle, error) {
+	ctx, span := p.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	puzzle, err := p.db.puzzle(ctx, id, false)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return &puzzle, nil
+}
+
+func (p *puzzle) GetCreated(ctx context.Context, id string, opts domains.PuzzleCursorPaginationOpts) ([]domains.PuzzleSummary, error) {
+	ctx, span := p.tracer.Start(ctx, "GetCreated", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor time.Time
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = decoded
+	}
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	puzzles := make([]domains.PuzzleSummary, 0)
+	for _, puzzle := range p.db.puzzles {
+		if puzzle.UserID != id || !puzzle.DeletedAt.IsZero() || !puzzle.HiddenAt.IsZero() {
+			continue
+		}
+		if !cursor.IsZero() && puzzle.CreatedAt.After(cursor) {
+			continue
+		}
+
+		puzzles = append(puzzles, p.db.puzzleSummary(ctx, puzzle))
+	}
+
+	sort.Slice(puzzles, func(i, j int) bool {
+		return puzzles[i].CreatedAt.After(puzzles[j].CreatedAt)
+	})
+
+	return limit(puzzles, opts.Limit+1), nil
+}
+
+func (p *puzzle) GetLiked(ctx context.Context, id string, opts domains.PuzzleCursorPaginationOpts) ([]domains.PuzzleSummary, error) {
+	ctx, span := p.tracer.Start(ctx, "GetLiked", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor time.Time
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = decoded
+	}
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	puzzles := make([]domains.PuzzleSummary, 0)
+	for _, like := range p.db.likes {
+		if like.UserID != id || !like.Active {
+			continue
+		}
+		if !cursor.IsZero() && like.UpdatedAt.After(cursor) {
+			continue
+		}
+
+		puzzle, ok := p.db.puzzles[like.PuzzleID]
+		if !ok || !puzzle.DeletedAt.IsZero() || !puzzle.HiddenAt.IsZero() {
+			continue
+		}
+
+		summary := p.db.puzzleSummary(ctx, puzzle)
+		summary.UserLikedAt = bun.NullTime{Time: like.UpdatedAt}
+
+		puzzles = append(puzzles, summary)
+	}
+
+	sort.Slice(puzzles, func(i, j int) bool {
+		return puzzles[i].UserLikedAt.Time.After(puzzles[j].UserLikedAt.Time)
+	})
+
+	return limit(puzzles, opts.Limit+1), nil
+}
+
+func (p *puzzle) GetRecent(ctx context.Context, opts domains.PuzzleCursorPaginationOpts) ([]domains.Puzzle, error) {
+	ctx, span := p.tracer.Start(ctx, "GetRecent", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor time.Time
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = decoded
+	}
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	puzzles := make([]domains.Puzzle, 0)
+	for _, stored := range p.recent(ctx) {
+		if opts.Language != "" && stored.Language != opts.Language {
+			continue
+		}
+		if !cursor.IsZero() {
+			if opts.Direction == "B" && stored.CreatedAt.Before(cursor) {
+				continue
+			}
+			if opts.Direction != "B" && stored.CreatedAt.After(cursor) {
+				continue
+			}
+		}
+
+		puzzle, err := p.db.puzzle(ctx, stored.ID, false)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		puzzles = append(puzzles, puzzle)
+	}
+
+	sort.Slice(puzzles, func(i, j int) bool {
+		if opts.Direction == "B" {
+			return puzzles[i].CreatedAt.Before(puzzles[j].CreatedAt)
+		}
+
+		return puzzles[i].CreatedAt.After(puzzles[j].CreatedAt)
+	})
+
+	return limit(puzzles, opts.Limit), nil
+}
+
+func (p *puzzle) GetNextForRecent(ctx context.Context, cursor string, language string) (*domains.Puzzle, error) {
+	ctx, span := p.tracer.Start(ctx, "GetNextForRecent", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	parsed, err := parseCursor(cursor)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	var next *domains.Puzzle
+	for _, puzzle := range p.recent(ctx) {
+		if language != "" && puzzle.Language != language {
+			continue
+		}
+		if !puzzle.CreatedAt.Before(parsed) {
+			continue
+		}
+		if next == nil || puzzle.CreatedAt.After(next.CreatedAt) {
+			puzzle = copyPuzzle(puzzle)
+			next = &puzzle
+		}
+	}
+
+	return next, nil
+}
+
+func (p *puzzle) GetPreviousForRecent(ctx context.Context, cursor string, language string) (*domains.Puzzle, error) {
+	ctx, span := p.tracer.Start(ctx, "GetPreviousForRecent", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	parsed, err := parseCursor(cursor)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	var previous *domains.Puzzle
+	for _, puzzle := range p.recent(ctx) {
+		if language != "" && puzzle.Language != language {
+			continue
+		}
+		if !puzzle.CreatedAt.After(parsed) {
+			continue
+		}
+		if previous == nil || puzzle.CreatedAt.Before(previous.CreatedAt) {
+			puzzle = copyPuzzle(puzzle)
+			previous = &puzzle
+		}
+	}
+
+	return previous, nil
+}
+
+func (p *puzzle) GetTagged(ctx context.Context, slug string, opts domains.PuzzleCursorPaginationOpts) ([]domains.PuzzleSummary, error) {
+	ctx, span := p.tracer.Start(ctx, "GetTagged", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor time.Time
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = decoded
+	}
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	puzzles := make([]domains.PuzzleSummary, 0)
+	for _, puzzle := range p.db.puzzles {
+		if !slices.Contains(p.db.puzzleTags[puzzle.ID], slug) || !puzzle.DeletedAt.IsZero() || !puzzle.HiddenAt.IsZero() {
+			continue
+		}
+		if !cursor.IsZero() && puzzle.CreatedAt.After(cursor) {
+			continue
+		}
+
+		puzzles = append(puzzles, p.db.puzzleSummary(ctx, puzzle))
+	}
+
+	sort.Slice(puzzles, func(i, j int) bool {
+		return puzzles[i].CreatedAt.After(puzzles[j].CreatedAt)
+	})
+
+	return limit(puzzles, opts.Limit+1), nil
+}
+
+func (p *puzzle) GetVersions(ctx context.Context, id string) ([]domains.PuzzleVersion, error) {
+	_, span := p.tracer.Start(ctx, "GetVersions", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	versions := make([]domains.PuzzleVersion, len(p.db.puzzleVersions[id]))
+	copy(versions, p.db.puzzleVersions[id])
+
+	return versions, nil
+}
+
+func (p *puzzle) Search(ctx context.Context, opts domains.PuzzleSearchOpts) ([]domains.PuzzleSummary, error) {
+	ctx, span := p.tracer.Start(ctx, "Search", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursorRank float64
+	var cursorCreatedAt time.Time
+	if !opts.Cursor.IsEmpty() {
+		decodedRank, decodedCreatedAt, err := opts.DecodeCursor()
+		if err == nil {
+			cursorCreatedAt, err = parseCursor(decodedCreatedAt)
+		}
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursorRank = decodedRank
+	}
+
+	userID, isAuthenticated := sessionUserID(ctx)
+	terms := searchTerms(opts.Query)
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	puzzles := make([]domains.PuzzleSummary, 0)
+	for _, puzzle := range p.db.puzzles {
+		if !puzzle.DeletedAt.IsZero() || !puzzle.HiddenAt.IsZero() {
+			continue
+		}
+		if opts.Difficulty != "" && puzzle.Difficulty != opts.Difficulty {
+			continue
+		}
+		if opts.Language != "" && puzzle.Language != opts.Language {
+			continue
+		}
+		if opts.UserID != "" && puzzle.UserID != opts.UserID {
+			continue
+		}
+		if opts.Unplayed && isAuthenticated && p.db.hasCompleted(puzzle.ID, userID) {
+			continue
+		}
+
+		rank, ok := searchRank(puzzle, terms)
+		if !ok {
+			continue
+		}
+		if !opts.Cursor.IsEmpty() && (rank > cursorRank || (rank == cursorRank && puzzle.CreatedAt.After(cursorCreatedAt))) {
+			continue
+		}
+
+		summary := p.db.puzzleSummary(ctx, puzzle)
+		summary.Rank = rank
+
+		puzzles = append(puzzles, summary)
+	}
+
+	sort.Slice(puzzles, func(i, j int) bool {
+		if puzzles[i].Rank != puzzles[j].Rank {
+			return puzzles[i].Rank > puzzles[j].Rank
+		}
+
+		return puzzles[i].CreatedAt.After(puzzles[j].CreatedAt)
+	})
+
+	return limit(puzzles, opts.Limit+1), nil
+}
+
+func (p *puzzle) ToggleLike(ctx context.Context, id string) (*domains.PuzzleLike, error) {
+	_, span := p.tracer.Start(ctx, "ToggleLike", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	session := domains.SessionFromContext(ctx)
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	now := truncate(time.Now())
+
+	key := likeKey(id, session.UserID.String)
+	like, ok := p.db.likes[key]
+	if ok {
+		like.Active = !like.Active
+		like.UpdatedAt = now
+	} else {
+		like = domains.PuzzleLike{
+			ID:     ulid.Make().String(),
+			Active: true,
+
+			CreatedAt: now,
+			UpdatedAt: now,
+
+			PuzzleID: id,
+			UserID:   session.UserID.String,
+		}
+	}
+
+	p.db.likes[key] = like
+
+	return &like, nil
+}
+
+func (p *puzzle) Update(ctx context.Context, payload domains.Puzzle) (*domains.Puzzle, error) {
+	_, span := p.tracer.Start(ctx, "Update", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	// Only update the puzzle if it hasn't been changed since the caller loaded it
+	stored, ok := p.db.puzzles[payload.ID]
+	if !ok || !stored.DeletedAt.IsZero() || stored.Version != payload.Version-1 {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrPuzzleOutdated)
+
+		return nil, repositories.ErrPuzzleOutdated
+	}
+
+	stored = copyPuzzle(stored)
+	stored.Difficulty = payload.Difficulty
+	stored.Version = payload.Version
+	stored.UpdatedAt = truncateNull(payload.UpdatedAt)
+	for _, group := range payload.Groups {
+		for i := range stored.Groups {
+			if stored.Groups[i].ID == group.ID {
+				stored.Groups[i].Description = group.Description
+			}
+		}
+	}
+	p.db.puzzles[payload.ID] = stored
+
+	// Games that were started before this edit stay pinned to the previous version
+	version := domains.NewPuzzleVersion(stored)
+	version.CreatedAt = stored.UpdatedAt.Time
+	p.db.puzzleVersions[payload.ID] = append(p.db.puzzleVersions[payload.ID], version)
+
+	payload.Tags = p.db.saveTags(payload.ID, payload.Tags)
+
+	return &payload, nil
+}
+
+// Returns the puzzles that can be shown in the recent feed which excludes deleted puzzles and, if authenticated,
+// puzzles that the user has already completed
+//
+// NOTE: Expects the caller to hold the lock
+func (p *puzzle) recent(ctx context.Context) []domains.Puzzle {
+	userID, isAuthenticated := sessionUserID(ctx)
+
+	puzzles := make([]domains.Puzzle, 0)
+	for _, puzzle := range p.db.puzzles {
+		if !puzzle.DeletedAt.IsZero() || !puzzle.HiddenAt.IsZero() {
+			continue
+		}
+		if isAuthenticated && p.db.hasCompleted(puzzle.ID, userID) {
+			continue
+		}
+
+		puzzles = append(puzzles, puzzle)
+	}
+
+	return puzzles
+}
+
+// Splits a search query into lowercased terms
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Approximates Postgres' full-text search by requiring every term to prefix a word in the puzzle. Like the search
+// document, matches in group descriptions are weighted higher than matches in block values
+func searchRank(puzzle domains.Puzzle, terms []string) (float64, bool) {
+	if len(terms) == 0 {
+		return 0, false
+	}
+
+	descriptions := make([]string, 0)
+	values := make([]string, 0)
+	for _, group := range puzzle.Groups {
+		descriptions = append(descriptions, searchTerms(group.Description)...)
+		for _, block := range group.Blocks {
+			values = append(values, searchTerms(block.Value)...)
+		}
+	}
+
+	rank := 0.0
+	for _, term := range terms {
+		matched := false
+		for _, word := range descriptions {
+			if strings.HasPrefix(word, term) {
+				matched = true
+				rank += 1.0
+			}
+		}
+		for _, word := range values {
+			if strings.HasPrefix(word, term) {
+				matched = true
+				rank += 0.4
+			}
+		}
+
+		if !matched {
+			return 0, false
+		}
+	}
+
+	// Round the same way that the Postgres repository does so that cursors behave the same
+	return math.Round(rank*1e6) / 1e6, true
+}
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Report = (*report)(nil)
+
+type report struct {
+	tracer trace.Tracer
+
+	db *DB
+}
+
+func NewReport(db *DB) repositories.Report {
+	logrus.Info("Created Report Memory Repository")
+
+	return &report{
+		tracer: telemetry.Tracer("memory.report"),
+
+		db: db,
+	}
+}
+
+func (r *report) Create(ctx context.Context, payload domains.Report) (*domains.Report, error) {
+	_, span := r.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, stored := range r.db.reports {
+		if stored.IsOpen() && stored.UserID == payload.UserID && stored.ReportedUserID == payload.ReportedUserID && stored.PuzzleID == payload.PuzzleID {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(repositories.ErrReportDuplicate)
+
+			return nil, repositories.ErrReportDuplicate
+		}
+	}
+
+	if payload.CreatedAt.IsZero() {
+		payload.CreatedAt = time.Now()
+	}
+	payload.CreatedAt = truncate(payload.CreatedAt)
+	payload.ReportedUser = domains.User{}
+	r.db.reports[payload.ID] = payload
+
+	payload.ReportedUser = r.db.users[payload.ReportedUserID]
+	return &payload, nil
+}
+
+func (r *report) Get(ctx context.Context, id string) (*domains.Report, error) {
+	_, span := r.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	report, ok := r.db.reports[id]
+	if !ok {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	report.ReportedUser = r.db.users[report.ReportedUserID]
+	return &report, nil
+}
+
+func (r *report) GetOpen(ctx context.Context, opts domains.ReportCursorPaginationOpts) ([]domains.Report, error) {
+	_, span := r.tracer.Start(ctx, "GetOpen", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	var cursor time.Time
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		cursor = decoded
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	reports := make([]domains.Report, 0)
+	for _, report := range r.db.reports {
+		if !report.IsOpen() {
+			continue
+		}
+		if !cursor.IsZero() && report.CreatedAt.Before(cursor) {
+			continue
+		}
+
+		report.ReportedUser = r.db.users[report.ReportedUserID]
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].CreatedAt.Before(reports[j].CreatedAt)
+	})
+
+	return limit(reports, opts.Limit+1), nil
+}
+
+func (r *report) Resolve(ctx context.Context, payload domains.Report) (*domains.Report, error) {
+	_, span := r.tracer.Start(ctx, "Resolve", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored, ok := r.db.reports[payload.ID]
+	if !ok || !stored.IsOpen() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrReportResolved)
+
+		return nil, repositories.ErrReportResolved
+	}
+
+	resolvedAt := truncateNull(payload.ResolvedAt)
+
+	// Returns whether the action also settles the given report
+	isSettled := func(report domains.Report) bool {
+		return false
+	}
+	switch payload.Action {
+	case "HIDE_PUZZLE":
+		if puzzle, ok := r.db.puzzles[stored.PuzzleID]; ok && puzzle.HiddenAt.IsZero() {
+			puzzle.HiddenAt = resolvedAt
+			r.db.puzzles[puzzle.ID] = puzzle
+		}
+
+		isSettled = func(report domains.Report) bool {
+			return report.PuzzleID == stored.PuzzleID
+		}
+	case "SUSPEND_USER":
+		if user, ok := r.db.users[stored.ReportedUserID]; ok && user.SuspendedAt.IsZero() {
+			user.SuspendedAt = resolvedAt
+			r.db.users[user.ID] = user
+		}
+
+		isSettled = func(report domains.Report) bool {
+			return report.ReportedUserID == stored.ReportedUserID
+		}
+	}
+
+	for id, report := range r.db.reports {
+		if id != stored.ID && (!report.IsOpen() || !isSettled(report)) {
+			continue
+		}
+
+		report.Action = payload.Action
+		report.ResolvedAt = resolvedAt
+		report.ResolvedBy = payload.ResolvedBy
+		r.db.reports[id] = report
+	}
+
+	resolved := r.db.reports[stored.ID]
+	resolved.ReportedUser = r.db.users[resolved.ReportedUserID]
+	return &resolved, nil
+}
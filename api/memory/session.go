@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Session = (*session)(nil)
+
+type session struct {
+	tracer trace.Tracer
+
+	db *DB
+}
+
+func NewSession(db *DB) repositories.Session {
+	logrus.Info("Created Session Memory Repository")
+
+	return &session{
+		tracer: telemetry.Tracer("memory.session"),
+
+		db: db,
+	}
+}
+
+func (s *session) Create(ctx context.Context, payload domains.Session) (*domains.Session, error) {
+	_, span := s.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if payload.CreatedAt.IsZero() {
+		payload.CreatedAt = time.Now()
+	}
+	payload.CreatedAt = truncate(payload.CreatedAt)
+	payload.AuthenticatedAt = truncateNull(payload.AuthenticatedAt)
+	payload.ExpiresAt = truncateNull(payload.ExpiresAt)
+
+	stored := payload
+	stored.User = nil
+	s.db.sessions[payload.ID] = stored
+
+	return &payload, nil
+}
+
+func (s *session) Get(ctx context.Context, id string) (*domains.Session, error) {
+	_, span := s.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	// The user is loaded separately since it isn't stored alongside the session
+	session, ok := s.db.sessions[id]
+	if !ok {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	if session.UserID.Valid {
+		if user, ok := s.db.users[session.UserID.String]; ok {
+			session.User = &user
+		}
+	}
+
+	return &session, nil
+}
+
+func (s *session) Update(ctx context.Context, payload domains.Session) (*domains.Session, error) {
+	_, span := s.tracer.Start(ctx, "Update", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.sessions[payload.ID]; ok {
+		payload.CreatedAt = truncate(payload.CreatedAt)
+		payload.AuthenticatedAt = truncateNull(payload.AuthenticatedAt)
+		payload.ExpiresAt = truncateNull(payload.ExpiresAt)
+
+		stored := payload
+		stored.User = nil
+		s.db.sessions[payload.ID] = stored
+	}
+
+	return &payload, nil
+}
+
+func (s *session) Delete(ctx context.Context, id string) error {
+	_, span := s.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	delete(s.db.sessions, id)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Tag = (*tag)(nil)
+
+type tag struct {
+	tracer trace.Tracer
+
+	db *DB
+}
+
+func NewTag(db *DB) repositories.Tag {
+	logrus.Info("Created Tag Memory Repository")
+
+	return &tag{
+		tracer: telemetry.Tracer("memory.tag"),
+
+		db: db,
+	}
+}
+
+func (t *tag) Get(ctx context.Context, slug string) (*domains.Tag, error) {
+	_, span := t.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	found, ok := t.db.tag(slug)
+	if !ok {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	return &found, nil
+}
+
+func (t *tag) Search(ctx context.Context, opts domains.TagSearchOpts) ([]domains.Tag, error) {
+	_, span := t.tracer.Start(ctx, "Search", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	prefix := domains.TagSlug(opts.Query)
+
+	tags := make([]domains.Tag, 0)
+	for slug := range t.db.tags {
+		if !strings.HasPrefix(slug, prefix) {
+			continue
+		}
+
+		found, _ := t.db.tag(slug)
+		if found.NumOfPuzzles == 0 {
+			continue
+		}
+
+		tags = append(tags, found)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].NumOfPuzzles != tags[j].NumOfPuzzles {
+			return tags[i].NumOfPuzzles > tags[j].NumOfPuzzles
+		}
+
+		return tags[i].Slug < tags[j].Slug
+	})
+
+	return limit(tags, opts.Limit), nil
+}
@@ -0,0 +1,270 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrConnectionNotAvailable = errors.New("Connection already belongs to another user.")
+)
+
+var _ repositories.User = (*user)(nil)
+
+type user struct {
+	tracer trace.Tracer
+
+	db *DB
+}
+
+func NewUser(db *DB) repositories.User {
+	logrus.Info("Created User Memory Repository")
+
+	return &user{
+		tracer: telemetry.Tracer("memory.user"),
+
+		db: db,
+	}
+}
+
+func (u *user) Create(ctx context.Context, connectionPayload domains.Connection, userPayload domains.User) (*domains.User, error) {
+	_, span := u.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+
+	var err error
+	if u.isUsernameTaken(userPayload.ID, userPayload.Username) {
+		err = repositories.ErrUserUsernameNotAvailable
+	}
+	for _, connection := range u.db.connections {
+		if connection.Sub == connectionPayload.Sub {
+			err = ErrConnectionNotAvailable
+		}
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	if userPayload.CreatedAt.IsZero() {
+		userPayload.CreatedAt = time.Now()
+	}
+	userPayload.CreatedAt = truncate(userPayload.CreatedAt)
+	userPayload.UpdatedAt = truncateNull(userPayload.UpdatedAt)
+
+	u.db.users[userPayload.ID] = userPayload
+	u.db.connections[connectionPayload.ID] = connectionPayload
+
+	return &userPayload, nil
+}
+
+func (u *user) Get(ctx context.Context, id string) (*domains.User, error) {
+	_, span := u.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	u.db.mu.RLock()
+	defer u.db.mu.RUnlock()
+
+	user, ok := u.db.users[id]
+	if !ok || !user.DeletedAt.IsZero() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	return &user, nil
+}
+
+func (u *user) GetStats(ctx context.Context, id string) (*domains.UserStats, error) {
+	_, span := u.tracer.Start(ctx, "GetStats", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	u.db.mu.RLock()
+	defer u.db.mu.RUnlock()
+
+	stats := domains.UserStats{}
+
+	attempts := 0
+	completed := make(map[string]int, 0)
+	won := make(map[string]int, 0)
+	counts := make(map[int]int, 0)
+	for _, game := range u.db.games {
+		// Like the join in Postgres, games are counted against the version of the puzzle that they were played on,
+		// including games for puzzles that have since been deleted
+		puzzle, ok := u.db.puzzleVersion(game.PuzzleID, game.PuzzleVersion)
+		if game.UserID != id || !ok {
+			continue
+		}
+
+		stats.GamesPlayed++
+		if game.CompletedAt.IsZero() {
+			continue
+		}
+
+		stats.GamesCompleted++
+		attempts += len(game.Attempts)
+		counts[len(game.Correct)]++
+
+		completed[puzzle.Difficulty]++
+		if len(game.Correct) == len(puzzle.Groups) {
+			won[puzzle.Difficulty]++
+
+			if len(game.Attempts) == len(game.Correct) {
+				stats.PerfectSolves++
+			}
+		}
+	}
+	if stats.GamesCompleted > 0 {
+		stats.AverageAttempts = float64(attempts) / float64(stats.GamesCompleted)
+	}
+
+	stats.Difficulties = make([]domains.UserStatsDifficulty, 0)
+	for difficulty, count := range completed {
+		stats.Difficulties = append(stats.Difficulties, domains.NewUserStatsDifficulty(difficulty, count, won[difficulty]))
+	}
+	stats.SortDifficulties()
+	stats.GroupsFound = domains.NewUserStatsGroupsFound(counts)
+
+	for _, puzzle := range u.db.puzzles {
+		if puzzle.UserID != id || !puzzle.DeletedAt.IsZero() {
+			continue
+		}
+
+		stats.PuzzlesCreated++
+		stats.LikesReceived += u.db.numOfLikes(puzzle.ID)
+	}
+
+	return &stats, nil
+}
+
+func (u *user) GetStreak(ctx context.Context, id string) (*domains.UserStreak, error) {
+	_, span := u.tracer.Start(ctx, "GetStreak", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	u.db.mu.RLock()
+	defer u.db.mu.RUnlock()
+
+	streak, ok := u.db.streaks[id]
+	if !ok {
+		streak = domains.NewUserStreak(id)
+	}
+
+	return &streak, nil
+}
+
+func (u *user) GetWithConnection(ctx context.Context, payload domains.Connection) (*domains.User, error) {
+	_, span := u.tracer.Start(ctx, "GetWithConnection", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	u.db.mu.RLock()
+	defer u.db.mu.RUnlock()
+
+	for _, connection := range u.db.connections {
+		if connection.Provider != payload.Provider || connection.Sub != payload.Sub {
+			continue
+		}
+
+		user, ok := u.db.users[connection.UserID]
+		if !ok || !user.DeletedAt.IsZero() {
+			break
+		}
+
+		return &user, nil
+	}
+
+	span.SetStatus(codes.Error, "")
+	span.RecordError(sql.ErrNoRows)
+
+	return nil, sql.ErrNoRows
+}
+
+func (u *user) Update(ctx context.Context, payload domains.User) (*domains.User, error) {
+	_, span := u.tracer.Start(ctx, "Update", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+
+	var err error
+	if stored, ok := u.db.users[payload.ID]; !ok || !stored.DeletedAt.IsZero() {
+		err = sql.ErrNoRows
+	} else if u.isUsernameTaken(payload.ID, payload.Username) {
+		err = repositories.ErrUserUsernameNotAvailable
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	payload.CreatedAt = truncate(payload.CreatedAt)
+	payload.UpdatedAt = truncateNull(payload.UpdatedAt)
+	u.db.users[payload.ID] = payload
+
+	return &payload, nil
+}
+
+func (u *user) Delete(ctx context.Context, id string) error {
+	_, span := u.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+
+	user, ok := u.db.users[id]
+	if !ok || !user.DeletedAt.IsZero() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return sql.ErrNoRows
+	}
+
+	for key, session := range u.db.sessions {
+		if session.UserID.String == id {
+			delete(u.db.sessions, key)
+		}
+	}
+	for key, connection := range u.db.connections {
+		if connection.UserID == id {
+			delete(u.db.connections, key)
+		}
+	}
+
+	// Keep the user so that their puzzles and games remain intact, but, strip it of anything identifiable
+	user.Anonymize()
+	user.UpdatedAt = truncateNull(user.UpdatedAt)
+	user.DeletedAt = truncateNull(user.DeletedAt)
+	u.db.users[id] = user
+
+	return nil
+}
+
+// Checks whether the username is used by any user other than the one with the given id. Deleted users are included
+// to match the unique constraint on the table
+//
+// NOTE: Expects the caller to hold the lock
+func (u *user) isUsernameTaken(id, username string) bool {
+	for _, user := range u.db.users {
+		if user.ID != id && user.Username == username {
+			return true
+		}
+	}
+
+	return false
+}
@@ -18,7 +18,7 @@ CREATE TABLE puzzle_groups (
   puzzle_id VARCHAR(26) NOT NULL REFERENCES puzzles (id),
   PRIMARY KEY(id)
 );
-CREATE INDEX puzzle_groups_idx ON puzzle_groups_idx (puzzle_id);
+CREATE INDEX puzzle_groups_idx ON puzzle_groups (puzzle_id);
 
 -- Blocks --
 CREATE TABLE puzzle_blocks(
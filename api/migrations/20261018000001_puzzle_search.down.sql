@@ -0,0 +1,3 @@
+DROP INDEX puzzles_search_idx;
+ALTER TABLE puzzles DROP COLUMN search;
+DROP FUNCTION puzzle_search_document;
@@ -0,0 +1,15 @@
+-- Builds the search document for a puzzle. Group descriptions are weighted higher than block values --
+CREATE FUNCTION puzzle_search_document(puzzle VARCHAR(26)) RETURNS TSVECTOR AS $$
+  SELECT
+    setweight(to_tsvector('english', COALESCE((SELECT string_agg(description, ' ') FROM puzzle_groups WHERE puzzle_id = puzzle), '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE((
+      SELECT string_agg(puzzle_blocks.value, ' ')
+        FROM puzzle_blocks
+        JOIN puzzle_groups ON puzzle_groups.id = puzzle_blocks.puzzle_group_id
+        WHERE puzzle_groups.puzzle_id = puzzle
+    ), '')), 'B')
+$$ LANGUAGE SQL STABLE;
+
+ALTER TABLE puzzles ADD COLUMN search TSVECTOR NOT NULL DEFAULT ''::TSVECTOR;
+UPDATE puzzles SET search = puzzle_search_document(id);
+CREATE INDEX puzzles_search_idx ON puzzles USING GIN (search);
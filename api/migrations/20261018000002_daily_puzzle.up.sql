@@ -0,0 +1,9 @@
+-- Daily Puzzles --
+CREATE TABLE daily_puzzles (
+  date DATE NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  puzzle_id VARCHAR(26) NOT NULL REFERENCES puzzles (id),
+  user_id VARCHAR(26) NOT NULL REFERENCES users (id),
+  PRIMARY KEY(date)
+);
+CREATE UNIQUE INDEX daily_puzzles_unique_idx ON daily_puzzles (puzzle_id);
@@ -0,0 +1,2 @@
+DROP TABLE user_streaks;
+ALTER TABLE users DROP COLUMN timezone;
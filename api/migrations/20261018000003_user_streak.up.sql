@@ -0,0 +1,16 @@
+-- Users --
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- User Streaks --
+CREATE TABLE user_streaks (
+  user_id VARCHAR(26) NOT NULL,
+  current_play INTEGER NOT NULL DEFAULT 0,
+  longest_play INTEGER NOT NULL DEFAULT 0,
+  current_win INTEGER NOT NULL DEFAULT 0,
+  longest_win INTEGER NOT NULL DEFAULT 0,
+  last_played_on DATE DEFAULT NULL,
+  last_won_on DATE DEFAULT NULL,
+  updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  PRIMARY KEY(user_id),
+  FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE games DROP CONSTRAINT games_puzzle_version_fkey;
+ALTER TABLE games DROP COLUMN puzzle_version;
+ALTER TABLE puzzles DROP COLUMN version;
+DROP TABLE puzzle_versions;
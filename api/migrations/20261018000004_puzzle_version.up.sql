@@ -0,0 +1,38 @@
+-- Puzzle Versions --
+CREATE TABLE puzzle_versions (
+  version INTEGER NOT NULL,
+  difficulty VARCHAR(12) NOT NULL,
+  max_attempts SMALLINT NOT NULL,
+  groups JSONB NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  puzzle_id VARCHAR(26) NOT NULL REFERENCES puzzles (id),
+  PRIMARY KEY(puzzle_id, version)
+);
+
+ALTER TABLE puzzles ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE games ADD COLUMN puzzle_version INTEGER NOT NULL DEFAULT 1;
+
+-- Snapshot the current content of every existing puzzle as its first version --
+INSERT INTO puzzle_versions (version, difficulty, max_attempts, groups, created_at, puzzle_id)
+  SELECT
+    1,
+    puzzles.difficulty,
+    puzzles.max_attempts,
+    COALESCE((
+      SELECT jsonb_agg(jsonb_build_object(
+        'id', puzzle_groups.id,
+        'description', puzzle_groups.description,
+        'blocks', COALESCE((
+          SELECT jsonb_agg(jsonb_build_object('id', puzzle_blocks.id, 'value', puzzle_blocks.value) ORDER BY puzzle_blocks.id)
+            FROM puzzle_blocks
+            WHERE puzzle_blocks.puzzle_group_id = puzzle_groups.id
+        ), '[]'::JSONB)
+      ) ORDER BY puzzle_groups.id)
+        FROM puzzle_groups
+        WHERE puzzle_groups.puzzle_id = puzzles.id
+    ), '[]'::JSONB),
+    COALESCE(puzzles.updated_at, puzzles.created_at),
+    puzzles.id
+  FROM puzzles;
+
+ALTER TABLE games ADD CONSTRAINT games_puzzle_version_fkey FOREIGN KEY (puzzle_id, puzzle_version) REFERENCES puzzle_versions (puzzle_id, version);
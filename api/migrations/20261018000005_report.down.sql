@@ -0,0 +1,3 @@
+DROP TABLE reports;
+ALTER TABLE users DROP COLUMN suspended_at;
+ALTER TABLE puzzles DROP COLUMN hidden_at;
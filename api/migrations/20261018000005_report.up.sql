@@ -0,0 +1,21 @@
+-- Moderation --
+ALTER TABLE puzzles ADD COLUMN hidden_at TIMESTAMPTZ NULL DEFAULT NULL;
+ALTER TABLE users ADD COLUMN suspended_at TIMESTAMPTZ NULL DEFAULT NULL;
+
+-- Reports --
+CREATE TABLE reports (
+  id VARCHAR(26) NOT NULL,
+  reason VARCHAR(16) NOT NULL,
+  details VARCHAR(512) NOT NULL DEFAULT '',
+  action VARCHAR(16) NULL DEFAULT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  resolved_at TIMESTAMPTZ NULL DEFAULT NULL,
+  puzzle_id VARCHAR(26) NULL DEFAULT NULL REFERENCES puzzles (id),
+  reported_user_id VARCHAR(26) NOT NULL REFERENCES users (id),
+  resolved_by VARCHAR(26) NULL DEFAULT NULL REFERENCES users (id),
+  user_id VARCHAR(26) NOT NULL REFERENCES users (id),
+  PRIMARY KEY(id)
+);
+-- A user can only have one open report against the same puzzle, or user, at a time
+CREATE UNIQUE INDEX reports_open_unique_idx ON reports (user_id, reported_user_id, COALESCE(puzzle_id, '')) WHERE resolved_at IS NULL;
+CREATE INDEX reports_open_idx ON reports (created_at) WHERE resolved_at IS NULL;
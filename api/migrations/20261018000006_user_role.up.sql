@@ -0,0 +1,3 @@
+-- Users --
+-- Existing users keep being able to create puzzles
+ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'CREATOR';
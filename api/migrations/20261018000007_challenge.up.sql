@@ -0,0 +1,13 @@
+-- Challenges --
+CREATE TABLE challenges (
+  id VARCHAR(26) NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  accepted_at TIMESTAMPTZ NULL DEFAULT NULL,
+  game_id VARCHAR(26) NOT NULL REFERENCES games (id),
+  puzzle_id VARCHAR(26) NOT NULL REFERENCES puzzles (id),
+  user_id VARCHAR(26) NOT NULL REFERENCES users (id),
+  opponent_id VARCHAR(26) NULL DEFAULT NULL REFERENCES users (id),
+  PRIMARY KEY(id)
+);
+CREATE INDEX challenges_user_idx ON challenges (user_id, created_at);
+CREATE INDEX challenges_opponent_idx ON challenges (opponent_id, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE puzzles DROP COLUMN group_size;
+ALTER TABLE puzzles DROP COLUMN group_count;
@@ -0,0 +1,4 @@
+-- Puzzles --
+-- Existing puzzles were all made with the default 4x4 grid
+ALTER TABLE puzzles ADD COLUMN group_count SMALLINT NOT NULL DEFAULT 4;
+ALTER TABLE puzzles ADD COLUMN group_size SMALLINT NOT NULL DEFAULT 4;
@@ -0,0 +1,2 @@
+DROP TABLE game_hints;
+ALTER TABLE puzzle_groups DROP COLUMN hint;
@@ -0,0 +1,14 @@
+-- Puzzle Groups --
+ALTER TABLE puzzle_groups ADD COLUMN hint VARCHAR(512) NULL DEFAULT NULL;
+
+-- Game Hints --
+CREATE TABLE game_hints (
+  id VARCHAR(26) NOT NULL,
+  "order" SMALLINT NOT NULL DEFAULT 0,
+  type VARCHAR(8) NOT NULL,
+  puzzle_group_id VARCHAR(26) NOT NULL REFERENCES puzzle_groups (id),
+  puzzle_block_id VARCHAR(26) NULL DEFAULT NULL REFERENCES puzzle_blocks (id),
+  game_id VARCHAR(26) NOT NULL REFERENCES games (id),
+  PRIMARY KEY(id)
+);
+CREATE UNIQUE INDEX game_hints_unique_idx ON game_hints (game_id, type, puzzle_group_id);
@@ -0,0 +1,17 @@
+CREATE OR REPLACE FUNCTION puzzle_search_document(puzzle VARCHAR(26)) RETURNS TSVECTOR AS $$
+  SELECT
+    setweight(to_tsvector('english', COALESCE((SELECT string_agg(description, ' ') FROM puzzle_groups WHERE puzzle_id = puzzle), '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE((
+      SELECT string_agg(puzzle_blocks.value, ' ')
+        FROM puzzle_blocks
+        JOIN puzzle_groups ON puzzle_groups.id = puzzle_blocks.puzzle_group_id
+        WHERE puzzle_groups.puzzle_id = puzzle
+    ), '')), 'B')
+$$ LANGUAGE SQL STABLE;
+UPDATE puzzles SET search = puzzle_search_document(id);
+
+ALTER TABLE puzzle_blocks ALTER COLUMN value TYPE VARCHAR(48);
+ALTER TABLE puzzle_groups ALTER COLUMN hint TYPE VARCHAR(512);
+
+DROP INDEX puzzles_language_idx;
+ALTER TABLE puzzles DROP COLUMN language;
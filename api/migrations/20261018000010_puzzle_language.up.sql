@@ -0,0 +1,26 @@
+-- Puzzles --
+ALTER TABLE puzzles ADD COLUMN language VARCHAR(8) NOT NULL DEFAULT 'en';
+CREATE INDEX puzzles_language_idx ON puzzles (language, created_at);
+
+-- Content is limited by graphemes so columns must fit characters that take up more than one code point --
+ALTER TABLE puzzle_groups ALTER COLUMN hint TYPE VARCHAR(2048);
+ALTER TABLE puzzle_blocks ALTER COLUMN value TYPE VARCHAR(192);
+
+-- Search documents also include unstemmed words so that puzzles in other languages can be found --
+CREATE OR REPLACE FUNCTION puzzle_search_document(puzzle VARCHAR(26)) RETURNS TSVECTOR AS $$
+  WITH content AS (
+    SELECT
+      COALESCE((SELECT string_agg(description, ' ') FROM puzzle_groups WHERE puzzle_id = puzzle), '') AS descriptions,
+      COALESCE((
+        SELECT string_agg(puzzle_blocks.value, ' ')
+          FROM puzzle_blocks
+          JOIN puzzle_groups ON puzzle_groups.id = puzzle_blocks.puzzle_group_id
+          WHERE puzzle_groups.puzzle_id = puzzle
+      ), '') AS values
+  )
+  SELECT
+    setweight(to_tsvector('english', descriptions) || to_tsvector('simple', descriptions), 'A') ||
+    setweight(to_tsvector('english', values) || to_tsvector('simple', values), 'B')
+  FROM content
+$$ LANGUAGE SQL STABLE;
+UPDATE puzzles SET search = puzzle_search_document(id);
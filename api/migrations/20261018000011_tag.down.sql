@@ -0,0 +1,2 @@
+DROP TABLE puzzle_tags;
+DROP TABLE tags;
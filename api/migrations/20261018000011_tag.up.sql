@@ -0,0 +1,19 @@
+-- Tags --
+CREATE TABLE tags (
+  id VARCHAR(26) NOT NULL,
+  slug VARCHAR(128) NOT NULL,
+  name VARCHAR(128) NOT NULL,
+  num_of_puzzles INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  PRIMARY KEY(id)
+);
+CREATE UNIQUE INDEX tags_slug_idx ON tags (slug);
+CREATE INDEX tags_num_of_puzzles_idx ON tags (num_of_puzzles DESC, slug);
+
+-- Puzzle Tags --
+CREATE TABLE puzzle_tags (
+  puzzle_id VARCHAR(26) NOT NULL REFERENCES puzzles (id),
+  tag_id VARCHAR(26) NOT NULL REFERENCES tags (id),
+  PRIMARY KEY(puzzle_id, tag_id)
+);
+CREATE INDEX puzzle_tags_tag_idx ON puzzle_tags (tag_id);
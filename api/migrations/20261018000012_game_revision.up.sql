@@ -0,0 +1,3 @@
+-- Games are only saved on top of the revision that they were loaded from --
+ALTER TABLE games ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+UPDATE games SET revision = 1;
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS holds every up and down SQL migration so that they ship with the binary
+//
+//go:embed *.up.sql *.down.sql
+var FS embed.FS
@@ -86,12 +86,12 @@ INSERT INTO `puzzle_likes` (`id`, `puzzle_id`, `user_id`)
     ('01J7HCXQC4Q0TA2C5PTS9RSSG2', '01J4CTP7K48Y424NJVCS82G15R', '01J78GEQ9RMWZ7W7FPZNGNNT2J');
 
 -- Seed game --
-INSERT INTO `games` (`id`, `score`, `challenge_code`, `puzzle_id`, `user_id`)
+INSERT INTO `games` (`id`, `score`, `puzzle_id`, `user_id`)
   VALUES 
   -- First game --
-      ('01J7HD11F1DAM7FKHBTD268ZVW', 0, '01J7HD0WGMD7TDRHN0SJBQ7QPN', '01J4CTP7K48Y424NJVCS82G15R', '01J78GEQ9RMWZ7W7FPZNGNNT2J'),
+      ('01J7HD11F1DAM7FKHBTD268ZVW', 0, '01J4CTP7K48Y424NJVCS82G15R', '01J78GEQ9RMWZ7W7FPZNGNNT2J'),
   -- Second game --
-      ('01J7H45J702Z4RRPY9SRZWW8K8', 0, '01J7H46C331EDPSHHAF2355200', '01J7F0DACH0M4N4S05K7892FTS', '01J78GEQ9RMWZ7W7FPZNGNNT2J');
+      ('01J7H45J702Z4RRPY9SRZWW8K8', 0, '01J7F0DACH0M4N4S05K7892FTS', '01J78GEQ9RMWZ7W7FPZNGNNT2J');
 
 -- Seed game correct --
 INSERT INTO `game_corrects` (`id`, `puzzle_group_id`, `game_id`)
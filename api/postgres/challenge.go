@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Challenge = (*challenge)(nil)
+
+type challenge struct {
+	tracer trace.Tracer
+
+	db *bun.DB
+}
+
+func NewChallenge(db *bun.DB) repositories.Challenge {
+	logrus.Info("Created Challenge Postgres Repository")
+
+	return &challenge{
+		tracer: telemetry.Tracer("postgres.challenge"),
+
+		db: db,
+	}
+}
+
+func (c *challenge) Create(ctx context.Context, payload domains.Challenge) (*domains.Challenge, error) {
+	ctx, span := c.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	if _, err := c.db.NewInsert().Model(&payload).Exec(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return c.Get(ctx, payload.ID)
+}
+
+func (c *challenge) Get(ctx context.Context, id string) (*domains.Challenge, error) {
+	ctx, span := c.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var challenge domains.Challenge
+	if err := c.db.
+		NewSelect().
+		Model(&challenge).
+		Relation("User", withDeletedUsers).
+		Relation("Opponent", withDeletedUsers).
+		Where("challenge.id = ?", id).
+		Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return &challenge, nil
+}
+
+func (c *challenge) GetForUser(ctx context.Context, id string, opts domains.ChallengeCursorPaginationOpts) ([]domains.Challenge, error) {
+	ctx, span := c.tracer.Start(ctx, "GetForUser", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var challenges []domains.Challenge
+	query := c.db.
+		NewSelect().
+		Model(&challenges).
+		Relation("User", withDeletedUsers).
+		Relation("Opponent", withDeletedUsers).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("challenge.user_id = ?", id).
+				WhereOr("challenge.opponent_id = ?", id)
+		}).
+		OrderExpr("challenge.created_at DESC").
+		Limit(opts.Limit + 1)
+
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := opts.Cursor.Decode()
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		query = query.Where("challenge.created_at <= ?", decoded)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return challenges, nil
+}
+
+func (c *challenge) Accept(ctx context.Context, payload domains.Challenge) (*domains.Challenge, error) {
+	ctx, span := c.tracer.Start(ctx, "Accept", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	// Only the first user to accept the challenge becomes the opponent
+	res, err := c.db.NewUpdate().
+		Model(&payload).
+		Column("accepted_at", "opponent_id").
+		WherePK().
+		Where("accepted_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if rows == 0 {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrChallengeAccepted)
+
+		return nil, repositories.ErrChallengeAccepted
+	}
+
+	return c.Get(ctx, payload.ID)
+}
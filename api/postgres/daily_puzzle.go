@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.DailyPuzzle = (*dailyPuzzle)(nil)
+
+type dailyPuzzle struct {
+	tracer trace.Tracer
+
+	db *bun.DB
+}
+
+func NewDailyPuzzle(db *bun.DB) repositories.DailyPuzzle {
+	logrus.Info("Created Daily Puzzle Postgres Repository")
+
+	return &dailyPuzzle{
+		tracer: telemetry.Tracer("postgres.daily_puzzle"),
+
+		db: db,
+	}
+}
+
+func (d *dailyPuzzle) Create(ctx context.Context, payload domains.DailyPuzzle) (*domains.DailyPuzzle, error) {
+	ctx, span := d.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var dailyPuzzle domains.DailyPuzzle
+	_, err := d.db.NewInsert().Model(&payload).Returning("*").Exec(ctx, &dailyPuzzle)
+	if err != nil && IsUniqueError(err) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrDailyPuzzleNotAvailable)
+
+		return nil, repositories.ErrDailyPuzzleNotAvailable
+	} else if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return &dailyPuzzle, nil
+}
+
+func (d *dailyPuzzle) Delete(ctx context.Context, date string) error {
+	ctx, span := d.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	if _, err := d.db.NewDelete().Model((*domains.DailyPuzzle)(nil)).Where("date = ?", date).Exec(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (d *dailyPuzzle) Get(ctx context.Context, date string) (*domains.DailyPuzzle, error) {
+	ctx, span := d.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var dailyPuzzle domains.DailyPuzzle
+	if err := d.db.NewSelect().Model(&dailyPuzzle).Where("date = ?", date).Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return &dailyPuzzle, nil
+}
+
+func (d *dailyPuzzle) GetLatest(ctx context.Context) (*domains.DailyPuzzle, error) {
+	ctx, span := d.tracer.Start(ctx, "GetLatest", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var dailyPuzzle domains.DailyPuzzle
+	err := d.db.NewSelect().Model(&dailyPuzzle).OrderExpr("date DESC").Limit(1).Scan(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return &dailyPuzzle, nil
+}
+
+func (d *dailyPuzzle) GetPast(ctx context.Context, date string, opts domains.PuzzleCursorPaginationOpts) ([]domains.DailyPuzzle, error) {
+	ctx, span := d.tracer.Start(ctx, "GetPast", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var dailyPuzzles []domains.DailyPuzzle
+	query := d.db.
+		NewSelect().
+		Model(&dailyPuzzles).
+		Where("daily_puzzle.date < ?", date).
+		Where("EXISTS (?)", d.db.NewRaw("SELECT 1 FROM puzzles WHERE puzzles.id = daily_puzzle.puzzle_id AND puzzles.deleted_at IS NULL AND puzzles.hidden_at IS NULL")).
+		OrderExpr("daily_puzzle.date DESC").
+		Limit(opts.Limit + 1)
+
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := opts.Cursor.Decode()
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		query = query.Where("daily_puzzle.date <= ?", decoded)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return dailyPuzzles, nil
+}
+
+func (d *dailyPuzzle) GetUpcoming(ctx context.Context, date string, limit int) ([]domains.DailyPuzzle, error) {
+	ctx, span := d.tracer.Start(ctx, "GetUpcoming", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var dailyPuzzles []domains.DailyPuzzle
+	if err := d.db.
+		NewSelect().
+		Model(&dailyPuzzles).
+		Where("daily_puzzle.date >= ?", date).
+		OrderExpr("daily_puzzle.date ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return dailyPuzzles, nil
+}
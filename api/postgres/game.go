@@ -36,117 +36,226 @@ func NewGame(db *bun.DB) repositories.Game {
 	}
 }
 
-func (g *game) GetWithPuzzleID(ctx context.Context, id string) (*domains.Game, error) {
-	ctx, span := g.tracer.Start(ctx, "GetWithPuzzleID", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+func (g *game) Get(ctx context.Context, id string) (*domains.Game, error) {
+	ctx, span := g.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
 		semconv.DBSystemPostgreSQL,
 	))
 	defer span.End()
 
-	session := domains.SessionFromContext(ctx)
-
 	var game domains.Game
-	query := g.db.NewSelect().
+	if err := g.db.NewSelect().
 		Model(&game).
 		Relation("Puzzle", func(q *bun.SelectQuery) *bun.SelectQuery {
-			puzzleQuery := q.
-				Column("id", "difficulty", "max_attempts", "created_at", "updated_at", "user_id").
+			return q.
+				Column("id", "difficulty", "max_attempts", "version", "group_count", "group_size", "language", "created_at", "updated_at", "user_id").
 				ColumnExpr("(?) AS puzzle__num_of_likes", g.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = game.puzzle_id AND active = TRUE"))
-
-			if session != nil && session.IsAuthenticated() {
-				puzzleQuery = puzzleQuery.
-					ColumnExpr("(?) AS puzzle__liked_at", g.db.NewRaw("SELECT updated_at FROM puzzle_likes WHERE puzzle_id = game.puzzle_id AND active = TRUE AND user_id = ?", session.UserID.String))
-			}
-
-			return puzzleQuery
 		}).
 		Relation("Puzzle.Groups").
 		Relation("Puzzle.Groups.Blocks").
-		Relation("Puzzle.CreatedBy").
-		Relation("User").
-		Where("puzzle_id = ?", id).
-		Where("game.user_id = ?", session.UserID).
-		Group("game.id", "puzzle.id", "puzzle__created_by.id", "user.id")
+		Relation("Puzzle.CreatedBy", withDeletedUsers).
+		Relation("User", withDeletedUsers).
+		Where("puzzle.deleted_at IS NULL").
+		Where("game.id = ?", id).
+		Group("game.id", "puzzle.id", "puzzle__created_by.id", "user.id").
+		Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
 
-	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := g.attempts(ctx, &game); err != nil {
 		span.SetStatus(codes.Error, "")
 		span.RecordError(err)
 
 		return nil, err
 	}
 
-	game.Attempts = make([][]string, 0)
-	game.Correct = make([]string, 0)
+	return &game, nil
+}
 
-	eg := errgroup.Group{}
-	eg.Go(func() error {
-		attempts := make([]domains.GameAttempt, 0)
+func (g *game) GetCorrect(ctx context.Context, ids []string) (map[string][]string, error) {
+	ctx, span := g.tracer.Start(ctx, "GetCorrect", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
 
-		err := g.db.NewSelect().
-			Model(&attempts).
-			Where("game_id = ?", game.ID).
-			Order("attempt_order ASC", "selection_order ASC").
-			Group("id", "attempt_order").
-			Scan(ctx)
-		if err != nil && !errors.Is(err, sql.ErrNoRows) {
-			return err
-		}
+	correct := make(map[string][]string, 0)
 
-		grouped := make(map[int][]domains.GameAttempt, 0)
-		for _, attempt := range attempts {
-			grouped[attempt.AttemptOrder] = append(grouped[attempt.AttemptOrder], attempt)
-		}
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() || len(ids) == 0 {
+		return correct, nil
+	}
 
-		for i := 0; i < len(grouped); i += 1 {
-			group, ok := grouped[i]
-			if !ok {
-				continue
-			}
+	var rows []struct {
+		PuzzleID      string
+		PuzzleGroupID string
+	}
+	err := g.db.NewSelect().
+		Model((*domains.GameCorrect)(nil)).
+		ColumnExpr("game.puzzle_id, game_correct.puzzle_group_id").
+		Join("JOIN games AS game ON game.id = game_correct.game_id").
+		Where("game.user_id = ?", session.UserID).
+		Where("game.puzzle_id IN (?)", bun.In(ids)).
+		Order("game_correct.order ASC").
+		Scan(ctx, &rows)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
 
-			sort.Slice(group, func(i, j int) bool {
-				return group[i].SelectionOrder < group[j].SelectionOrder
-			})
+		return nil, err
+	}
 
-			ids := make([]string, 0)
-			for _, attempt := range group {
-				ids = append(ids, attempt.PuzzleBlockID)
-			}
+	for _, row := range rows {
+		correct[row.PuzzleID] = append(correct[row.PuzzleID], row.PuzzleGroupID)
+	}
 
-			game.Attempts = append(game.Attempts, ids)
-		}
+	return correct, nil
+}
 
-		return nil
-	})
+func (g *game) GetLeaderboard(ctx context.Context, id string, opts domains.LeaderboardOpts) ([]domains.LeaderboardEntry, error) {
+	ctx, span := g.tracer.Start(ctx, "GetLeaderboard", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
 
-	eg.Go(func() error {
-		correct := make([]domains.GameCorrect, 0)
+	var entries []domains.LeaderboardEntry
+	query := g.leaderboard(&entries, []string{id}).
+		OrderExpr("leaderboard_entry.rank ASC, leaderboard_entry.attempts ASC, leaderboard_entry.timed DESC, leaderboard_entry.solve_time ASC, leaderboard_entry.id ASC").
+		Limit(opts.Limit + 1)
 
-		err := g.db.NewSelect().
-			Model(&correct).
-			Where("game_id = ?", game.ID).
-			Order("order ASC").
-			Scan(ctx)
-		if err != nil && !errors.Is(err, sql.ErrNoRows) {
-			return err
+	if !opts.Cursor.IsEmpty() {
+		cursor, err := opts.DecodeCursor()
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
 		}
 
-		sort.Slice(correct, func(i, j int) bool {
-			return correct[i].Order < correct[j].Order
+		query = query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("leaderboard_entry.score < ?", cursor.Score).
+				WhereOr("leaderboard_entry.score = ? AND (leaderboard_entry.attempts, NOT leaderboard_entry.timed, leaderboard_entry.solve_time, leaderboard_entry.id) >= (?, ?, ?, ?)", cursor.Score, cursor.Attempts, !cursor.Timed, cursor.SolveTime, cursor.ID)
 		})
+	}
 
-		for _, c := range correct {
-			game.Correct = append(game.Correct, c.PuzzleGroupID)
+	if err := query.Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (g *game) GetLeaderboardEntry(ctx context.Context, id string, userID string) (*domains.LeaderboardEntry, error) {
+	ctx, span := g.tracer.Start(ctx, "GetLeaderboardEntry", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var entry domains.LeaderboardEntry
+	if err := g.leaderboard(&entry, []string{id}).Where("leaderboard_entry.user_id = ?", userID).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
 		}
 
-		return nil
-	})
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
 
-	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (g *game) GetLeaderboardEntries(ctx context.Context, ids []string, userIDs []string) ([]domains.LeaderboardEntry, error) {
+	ctx, span := g.tracer.Start(ctx, "GetLeaderboardEntries", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	entries := make([]domains.LeaderboardEntry, 0)
+	if len(ids) == 0 || len(userIDs) == 0 {
+		return entries, nil
+	}
+
+	if err := g.leaderboard(&entries, ids).Where("leaderboard_entry.user_id IN (?)", bun.In(userIDs)).Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
 		span.SetStatus(codes.Error, "")
 		span.RecordError(err)
 
 		return nil, err
 	}
 
+	return entries, nil
+}
+
+func (g *game) GetWithPuzzleID(ctx context.Context, id string) (*domains.Game, error) {
+	ctx, span := g.tracer.Start(ctx, "GetWithPuzzleID", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	session := domains.SessionFromContext(ctx)
+	// Games only belong to authenticated users
+	if session == nil || !session.IsAuthenticated() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(sql.ErrNoRows)
+
+		return nil, sql.ErrNoRows
+	}
+
+	var game domains.Game
+	query := g.db.NewSelect().
+		Model(&game).
+		Relation("Puzzle", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Column("id", "difficulty", "max_attempts", "version", "group_count", "group_size", "language", "created_at", "updated_at", "user_id").
+				ColumnExpr("(?) AS puzzle__num_of_likes", g.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = game.puzzle_id AND active = TRUE")).
+				ColumnExpr("(?) AS puzzle__liked_at", g.db.NewRaw("SELECT updated_at FROM puzzle_likes WHERE puzzle_id = game.puzzle_id AND active = TRUE AND user_id = ?", session.UserID.String))
+		}).
+		Relation("Puzzle.Groups").
+		Relation("Puzzle.Groups.Blocks").
+		Relation("Puzzle.CreatedBy", withDeletedUsers).
+		Relation("User", withDeletedUsers).
+		Where("puzzle.deleted_at IS NULL").
+		Where("puzzle_id = ?", id).
+		Where("game.user_id = ?", session.UserID).
+		Group("game.id", "puzzle.id", "puzzle__created_by.id", "user.id")
+
+	if err := query.Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	if err := g.attempts(ctx, &game); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	// Groups are edited in place so show the puzzle as it was when the game was started
+	var version domains.PuzzleVersion
+	err := g.db.NewSelect().
+		Model(&version).
+		Where("puzzle_id = ?", game.PuzzleID).
+		Where("version = ?", game.PuzzleVersion).
+		Scan(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if err == nil {
+		version.Pin(&game.Puzzle)
+	}
+
 	return &game, nil
 }
 
@@ -162,12 +271,18 @@ func (g *game) GetHistory(ctx context.Context, id string, opts domains.GameCurso
 	query := g.db.
 		NewSelect().
 		Model(&games).
-		Column("id", "score", "created_at", "completed_at", "puzzle_id", "user_id").
-		ColumnExpr("(?) AS attempts", g.db.NewRaw("SELECT COUNT(DISTINCT(attempt_order)) FROM game_attempts WHERE game_id = game_summary.id")).
+		Column("id", "score", "created_at", "completed_at", "puzzle_id", "puzzle_version", "user_id").
+		// Only count the attempts that didn't match a group
+		ColumnExpr("(?) - (?) AS attempts", g.db.NewRaw("SELECT COUNT(DISTINCT(attempt_order)) FROM game_attempts WHERE game_id = game_summary.id"), g.db.NewRaw("SELECT COUNT(id) FROM game_corrects WHERE game_id = game_summary.id")).
+		ColumnExpr("(?) AS hints", g.db.NewRaw("SELECT COUNT(id) FROM game_hints WHERE game_id = game_summary.id")).
 		Relation("Puzzle", func(q *bun.SelectQuery) *bun.SelectQuery {
+			// Include puzzles that have since been deleted so that the game can still be shown in the user's history
 			q = q.
-				Column("id", "difficulty", "max_attempts", "created_at", "updated_at", "user_id").
-				ColumnExpr("(?) AS puzzle__num_of_likes", g.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = game_summary.puzzle_id AND active = TRUE"))
+				Column("id", "max_attempts", "group_count", "group_size", "language", "created_at", "updated_at", "deleted_at", "user_id").
+				// Show the puzzle as it was when the game was played
+				ColumnExpr("(?) AS puzzle__difficulty", g.db.NewRaw("SELECT difficulty FROM puzzle_versions WHERE puzzle_id = game_summary.puzzle_id AND version = game_summary.puzzle_version")).
+				ColumnExpr("(?) AS puzzle__num_of_likes", g.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = game_summary.puzzle_id AND active = TRUE")).
+				WhereAllWithDeleted()
 
 			if session != nil && session.IsAuthenticated() {
 				q = q.ColumnExpr("(?) AS puzzle__me_liked_at", g.db.NewRaw("SELECT updated_at FROM puzzle_likes WHERE puzzle_id = game_summary.puzzle_id AND active = TRUE AND user_id = ?", session.UserID.String))
@@ -175,8 +290,8 @@ func (g *game) GetHistory(ctx context.Context, id string, opts domains.GameCurso
 
 			return q
 		}).
-		Relation("Puzzle.CreatedBy").
-		Relation("User").
+		Relation("Puzzle.CreatedBy", withDeletedUsers).
+		Relation("User", withDeletedUsers).
 		Where("game_summary.user_id = ?", id).
 		Group("game_summary.id", "puzzle.id", "puzzle__created_by.id", "user.id").
 		OrderExpr("game_summary.created_at DESC").
@@ -211,41 +326,76 @@ func (g *game) Save(ctx context.Context, payload domains.Game) (*domains.Game, e
 	defer span.End()
 
 	var game domains.Game
+	hints := make([]domains.GameHint, 0)
 	if err := g.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		_, err := g.db.NewInsert().
+		// Streaks should only be updated the first time that a game is completed
+		wasCompleted, err := tx.NewSelect().
+			Model((*domains.Game)(nil)).
+			Where("puzzle_id = ?", payload.PuzzleID).
+			Where("user_id = ?", payload.UserID).
+			Where("completed_at IS NOT NULL").
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+
+		// New games are created when they're started. Games that are completed in the same save that creates them are
+		// created when they're completed instead so that they're left untimed on the leaderboard
+		createdAt := payload.CreatedAt
+		if !payload.CompletedAt.IsZero() {
+			createdAt = payload.CompletedAt.Time
+		}
+
+		_, err = tx.NewInsert().
 			Model(&domains.Game{
-				ID:    ulid.Make().String(),
-				Score: payload.Score,
+				ID:       ulid.Make().String(),
+				Score:    payload.Score,
+				Revision: 1,
 
-				CreatedAt:   payload.CompletedAt.Time,
+				CreatedAt:   createdAt,
 				CompletedAt: payload.CompletedAt,
 
 				PuzzleID: payload.PuzzleID,
 				UserID:   payload.UserID,
 			}).
+			// Pin new games to the current version of the puzzle. Existing games keep the version that they were started on
+			Value("puzzle_version", "(SELECT version FROM puzzles WHERE id = ?)", payload.PuzzleID).
 			On("CONFLICT (puzzle_id, user_id) DO UPDATE").
 			Set("score = ?", payload.Score).
+			Set("revision = game.revision + 1").
 			Set("completed_at = ?", payload.CompletedAt).
+			// Only update the game if it hasn't been saved since the caller loaded it
+			Where("game.revision = ?", payload.Revision).
 			Returning("*").
 			Exec(ctx, &game)
+		if errors.Is(err, sql.ErrNoRows) {
+			return repositories.ErrGameOutdated
+		}
 		if err != nil {
 			return err
 		}
 
-		if _, err := g.db.NewDelete().
+		if _, err := tx.NewDelete().
 			Model((*domains.GameAttempt)(nil)).
 			Where("game_id = ?", game.ID).
 			Exec(ctx); err != nil {
 			return err
 		}
 
-		if _, err := g.db.NewDelete().
+		if _, err := tx.NewDelete().
 			Model((*domains.GameCorrect)(nil)).
 			Where("game_id = ?", game.ID).
 			Exec(ctx); err != nil {
 			return err
 		}
 
+		if _, err := tx.NewDelete().
+			Model((*domains.GameHint)(nil)).
+			Where("game_id = ?", game.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		if len(payload.Attempts) > 0 {
 			attempts := make([]domains.GameAttempt, 0)
 			for i, attempt := range payload.Attempts {
@@ -261,7 +411,7 @@ func (g *game) Save(ctx context.Context, payload domains.Game) (*domains.Game, e
 				}
 			}
 
-			if _, err := g.db.NewInsert().
+			if _, err := tx.NewInsert().
 				Model(&attempts).
 				Exec(ctx); err != nil {
 				return err
@@ -280,13 +430,33 @@ func (g *game) Save(ctx context.Context, payload domains.Game) (*domains.Game, e
 				})
 			}
 
-			if _, err := g.db.NewInsert().
+			if _, err := tx.NewInsert().
 				Model(&correct).
 				Exec(ctx); err != nil {
 				return err
 			}
 		}
 
+		if len(payload.Hints) > 0 {
+			for i, hint := range payload.Hints {
+				hint.ID = ulid.Make().String()
+				hint.Order = i
+				hint.GameID = game.ID
+
+				hints = append(hints, hint)
+			}
+
+			if _, err := tx.NewInsert().
+				Model(&hints).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		if !wasCompleted && !payload.CompletedAt.IsZero() {
+			return g.recordStreak(ctx, tx, payload)
+		}
+
 		return nil
 	}); err != nil {
 		span.SetStatus(codes.Error, "")
@@ -297,9 +467,169 @@ func (g *game) Save(ctx context.Context, payload domains.Game) (*domains.Game, e
 
 	game.Attempts = payload.Attempts
 	game.Correct = payload.Correct
+	game.Hints = hints
 
 	game.Puzzle = payload.Puzzle
 	game.User = payload.User
 
 	return &game, nil
 }
+
+// Updates the streaks of the game's user with the day, in the user's timezone, that the game was completed on
+func (g *game) recordStreak(ctx context.Context, tx bun.Tx, game domains.Game) error {
+	var user domains.User
+	if err := tx.NewSelect().
+		Model(&user).
+		Column("id", "timezone").
+		Where("id = ?", game.UserID).
+		Scan(ctx); err != nil {
+		return err
+	}
+
+	groups, err := tx.NewSelect().
+		Model((*domains.PuzzleGroup)(nil)).
+		Where("puzzle_id = ?", game.PuzzleID).
+		Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	streak := domains.NewUserStreak(game.UserID)
+	if err := tx.NewSelect().
+		Model(&streak).
+		Where("user_id = ?", game.UserID).
+		For("UPDATE").
+		Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	streak.Record(domains.StreakDay(game.CompletedAt.Time, user.Location()), len(game.Correct) == groups)
+
+	if _, err := tx.NewInsert().
+		Model(&streak).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("current_play = EXCLUDED.current_play").
+		Set("longest_play = EXCLUDED.longest_play").
+		Set("current_win = EXCLUDED.current_win").
+		Set("longest_win = EXCLUDED.longest_win").
+		Set("last_played_on = EXCLUDED.last_played_on").
+		Set("last_won_on = EXCLUDED.last_won_on").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Loads the attempts, correct groups, and, hints of the given game in the order that they were made
+func (g *game) attempts(ctx context.Context, game *domains.Game) error {
+	game.Attempts = make([][]string, 0)
+	game.Correct = make([]string, 0)
+	game.Hints = make([]domains.GameHint, 0)
+
+	eg := errgroup.Group{}
+	eg.Go(func() error {
+		attempts := make([]domains.GameAttempt, 0)
+
+		err := g.db.NewSelect().
+			Model(&attempts).
+			Where("game_id = ?", game.ID).
+			Order("attempt_order ASC", "selection_order ASC").
+			Group("id", "attempt_order").
+			Scan(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		grouped := make(map[int][]domains.GameAttempt, 0)
+		for _, attempt := range attempts {
+			grouped[attempt.AttemptOrder] = append(grouped[attempt.AttemptOrder], attempt)
+		}
+
+		for i := 0; i < len(grouped); i += 1 {
+			group, ok := grouped[i]
+			if !ok {
+				continue
+			}
+
+			sort.Slice(group, func(i, j int) bool {
+				return group[i].SelectionOrder < group[j].SelectionOrder
+			})
+
+			ids := make([]string, 0)
+			for _, attempt := range group {
+				ids = append(ids, attempt.PuzzleBlockID)
+			}
+
+			game.Attempts = append(game.Attempts, ids)
+		}
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		correct := make([]domains.GameCorrect, 0)
+
+		err := g.db.NewSelect().
+			Model(&correct).
+			Where("game_id = ?", game.ID).
+			Order("order ASC").
+			Scan(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		sort.Slice(correct, func(i, j int) bool {
+			return correct[i].Order < correct[j].Order
+		})
+
+		for _, c := range correct {
+			game.Correct = append(game.Correct, c.PuzzleGroupID)
+		}
+
+		return nil
+	})
+
+	eg.Go(func() error {
+		err := g.db.NewSelect().
+			Model(&game.Hints).
+			Where("game_id = ?", game.ID).
+			Order("order ASC").
+			Scan(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		return nil
+	})
+
+	return eg.Wait()
+}
+
+// Builds the query for every ranked, completed game for the given puzzles. Each game is ranked against the other games
+// of its puzzle, and, ranks are computed before any filters so that they stay the same regardless of which entries are
+// selected
+func (g *game) leaderboard(model interface{}, ids []string) *bun.SelectQuery {
+	entries := g.db.NewSelect().
+		TableExpr("games AS game").
+		ColumnExpr("game.id, game.score, game.created_at, game.completed_at, game.puzzle_version, game.puzzle_id, game.user_id").
+		ColumnExpr("(?) AS attempts", g.db.NewRaw("SELECT COUNT(DISTINCT(attempt_order)) FROM game_attempts WHERE game_id = game.id")).
+		ColumnExpr("(?) AS hints", g.db.NewRaw("SELECT COUNT(id) FROM game_hints WHERE game_id = game.id")).
+		ColumnExpr("game.completed_at > game.created_at AS timed").
+		ColumnExpr("FLOOR(EXTRACT(EPOCH FROM game.completed_at - game.created_at) * 1000)::BIGINT AS solve_time").
+		Where("game.puzzle_id IN (?)", bun.In(ids)).
+		Where("game.completed_at IS NOT NULL").
+		Where("game.user_id IS NOT NULL")
+
+	ranked := g.db.NewSelect().
+		TableExpr("(?) AS entry", entries).
+		ColumnExpr("entry.*").
+		ColumnExpr("RANK() OVER (PARTITION BY entry.puzzle_id ORDER BY entry.score DESC, entry.attempts ASC, entry.timed DESC, entry.solve_time ASC) AS rank")
+
+	return g.db.NewSelect().
+		Model(model).
+		ModelTableExpr("(?) AS leaderboard_entry", ranked).
+		ColumnExpr("leaderboard_entry.*").
+		Relation("User", withDeletedUsers)
+}
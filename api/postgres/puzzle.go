@@ -61,7 +61,18 @@ func (p *puzzle) Create(ctx context.Context, payload domains.Puzzle) (*domains.P
 			return err
 		}
 
-		return nil
+		version := domains.NewPuzzleVersion(payload)
+		if _, err := tx.NewInsert().Model(&version).Exec(ctx); err != nil {
+			return err
+		}
+
+		tags, err := saveTags(ctx, tx, payload.ID, payload.Tags)
+		if err != nil {
+			return err
+		}
+		puzzle.Tags = tags
+
+		return refreshSearch(ctx, tx, payload.ID)
 	})
 	if err != nil {
 		span.SetStatus(codes.Error, "")
@@ -88,6 +99,35 @@ func (p *puzzle) Create(ctx context.Context, payload domains.Puzzle) (*domains.P
 	return &puzzle, nil
 }
 
+func (p *puzzle) Delete(ctx context.Context, id string) error {
+	ctx, span := p.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	err := p.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().Model((*domains.Puzzle)(nil)).Where("id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+
+		// Deleted puzzles no longer count towards their tags
+		ids, err := getTagIDs(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return refreshTagCounts(ctx, tx, ids)
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
+
 func (p *puzzle) Get(ctx context.Context, id string) (*domains.Puzzle, error) {
 	ctx, span := p.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
 		semconv.DBSystemPostgreSQL,
@@ -100,11 +140,12 @@ func (p *puzzle) Get(ctx context.Context, id string) (*domains.Puzzle, error) {
 	query := p.db.
 		NewSelect().
 		Model(&puzzle).
-		Column("puzzle.id", "puzzle.difficulty", "puzzle.max_attempts", "puzzle.created_at", "puzzle.updated_at", "puzzle.user_id").
+		Column("puzzle.id", "puzzle.difficulty", "puzzle.max_attempts", "puzzle.version", "puzzle.group_count", "puzzle.group_size", "puzzle.language", "puzzle.created_at", "puzzle.updated_at", "puzzle.hidden_at", "puzzle.user_id").
 		ColumnExpr("(?) AS num_of_likes", p.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = puzzle.id AND active = TRUE")).
 		Relation("Groups").
 		Relation("Groups.Blocks").
-		Relation("CreatedBy").
+		Relation("CreatedBy", withDeletedUsers).
+		Where("puzzle.deleted_at IS NULL").
 		Where("puzzle.id = ?", id)
 
 	if session != nil && session.IsAuthenticated() {
@@ -119,6 +160,15 @@ func (p *puzzle) Get(ctx context.Context, id string) (*domains.Puzzle, error) {
 		return nil, err
 	}
 
+	tags, err := getTags(ctx, p.db, []string{puzzle.ID})
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	puzzle.Tags = tags[puzzle.ID]
+
 	return &puzzle, nil
 }
 
@@ -134,10 +184,12 @@ func (p *puzzle) GetCreated(ctx context.Context, id string, opts domains.PuzzleC
 	query := p.db.
 		NewSelect().
 		Model(&puzzles).
-		Column("puzzle_summary.id", "puzzle_summary.difficulty", "puzzle_summary.max_attempts", "puzzle_summary.created_at", "puzzle_summary.updated_at", "puzzle_summary.user_id").
+		Column("puzzle_summary.id", "puzzle_summary.difficulty", "puzzle_summary.max_attempts", "puzzle_summary.group_count", "puzzle_summary.group_size", "puzzle_summary.language", "puzzle_summary.created_at", "puzzle_summary.updated_at", "puzzle_summary.user_id").
 		ColumnExpr("(?) AS num_of_likes", p.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = puzzle_summary.id AND active = TRUE")).
-		Relation("CreatedBy").
+		Relation("CreatedBy", withDeletedUsers).
+		Where("puzzle_summary.deleted_at IS NULL").
 		Where("puzzle_summary.user_id = ?", id).
+		Where("puzzle_summary.hidden_at IS NULL").
 		Group("puzzle_summary.id", "created_by.id").
 		OrderExpr("puzzle_summary.created_at DESC").
 		Limit(opts.Limit + 1)
@@ -165,6 +217,12 @@ func (p *puzzle) GetCreated(ctx context.Context, id string, opts domains.PuzzleC
 
 		return nil, err
 	}
+	if err := attachSummaryTags(ctx, p.db, puzzles); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
 
 	return puzzles, nil
 }
@@ -181,12 +239,14 @@ func (p *puzzle) GetLiked(ctx context.Context, id string, opts domains.PuzzleCur
 	query := p.db.
 		NewSelect().
 		Model(&puzzles).
-		Column("puzzle_summary.id", "puzzle_summary.difficulty", "puzzle_summary.max_attempts", "puzzle_summary.created_at", "puzzle_summary.updated_at", "puzzle_summary.user_id").
+		Column("puzzle_summary.id", "puzzle_summary.difficulty", "puzzle_summary.max_attempts", "puzzle_summary.group_count", "puzzle_summary.group_size", "puzzle_summary.language", "puzzle_summary.created_at", "puzzle_summary.updated_at", "puzzle_summary.user_id").
 		ColumnExpr("puzzle_like.updated_at AS user_liked_at").
 		ColumnExpr("(?) AS num_of_likes", p.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = puzzle_summary.id AND active = TRUE")).
-		Relation("CreatedBy").
+		Relation("CreatedBy", withDeletedUsers).
+		Where("puzzle_summary.deleted_at IS NULL").
 		Join("LEFT JOIN puzzle_likes AS puzzle_like").JoinOn("puzzle_id = puzzle_summary.id AND active = TRUE").
 		Where("puzzle_like.user_id = ?", id).
+		Where("puzzle_summary.hidden_at IS NULL").
 		Group("puzzle_summary.id", "created_by.id", "puzzle_like.updated_at").
 		OrderExpr("puzzle_like.updated_at DESC").
 		Limit(opts.Limit + 1)
@@ -214,6 +274,12 @@ func (p *puzzle) GetLiked(ctx context.Context, id string, opts domains.PuzzleCur
 
 		return nil, err
 	}
+	if err := attachSummaryTags(ctx, p.db, puzzles); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
 
 	return puzzles, nil
 }
@@ -230,11 +296,13 @@ func (p *puzzle) GetRecent(ctx context.Context, opts domains.PuzzleCursorPaginat
 	query := p.db.
 		NewSelect().
 		Model(&puzzles).
-		Column("puzzle.id", "puzzle.difficulty", "puzzle.max_attempts", "puzzle.created_at", "puzzle.updated_at", "puzzle.user_id").
+		Column("puzzle.id", "puzzle.difficulty", "puzzle.max_attempts", "puzzle.version", "puzzle.group_count", "puzzle.group_size", "puzzle.language", "puzzle.created_at", "puzzle.updated_at", "puzzle.user_id").
 		ColumnExpr("(?) AS num_of_likes", p.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = puzzle.id AND active = TRUE")).
 		Relation("Groups").
 		Relation("Groups.Blocks").
-		Relation("CreatedBy").
+		Relation("CreatedBy", withDeletedUsers).
+		Where("puzzle.deleted_at IS NULL").
+		Where("puzzle.hidden_at IS NULL").
 		Group("puzzle.id", "created_by.id").
 		Limit(opts.Limit)
 
@@ -248,6 +316,10 @@ func (p *puzzle) GetRecent(ctx context.Context, opts domains.PuzzleCursorPaginat
 			Where("game.id IS NULL")
 	}
 
+	if opts.Language != "" {
+		query = query.Where("puzzle.language = ?", opts.Language)
+	}
+
 	// Apply ORDER BY
 	if opts.Direction == "B" {
 		query = query.OrderExpr("puzzle.created_at ASC")
@@ -279,11 +351,17 @@ func (p *puzzle) GetRecent(ctx context.Context, opts domains.PuzzleCursorPaginat
 
 		return nil, err
 	}
+	if err := attachTags(ctx, p.db, puzzles); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
 
 	return puzzles, nil
 }
 
-func (p *puzzle) GetNextForRecent(ctx context.Context, cursor string) (*domains.Puzzle, error) {
+func (p *puzzle) GetNextForRecent(ctx context.Context, cursor string, language string) (*domains.Puzzle, error) {
 	ctx, span := p.tracer.Start(ctx, "GetNextForRecent", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
 		semconv.DBSystemPostgreSQL,
 	))
@@ -296,6 +374,7 @@ func (p *puzzle) GetNextForRecent(ctx context.Context, cursor string) (*domains.
 		NewSelect().
 		Model(&puzzle).
 		Where("puzzle.created_at < ?", cursor).
+		Where("puzzle.hidden_at IS NULL").
 		OrderExpr("puzzle.created_at DESC").
 		Limit(1)
 
@@ -308,6 +387,10 @@ func (p *puzzle) GetNextForRecent(ctx context.Context, cursor string) (*domains.
 			Where("game.id IS NULL")
 	}
 
+	if language != "" {
+		query = query.Where("puzzle.language = ?", language)
+	}
+
 	err := query.Scan(ctx)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		span.SetStatus(codes.Error, "")
@@ -322,7 +405,7 @@ func (p *puzzle) GetNextForRecent(ctx context.Context, cursor string) (*domains.
 	return &puzzle, nil
 }
 
-func (p *puzzle) GetPreviousForRecent(ctx context.Context, cursor string) (*domains.Puzzle, error) {
+func (p *puzzle) GetPreviousForRecent(ctx context.Context, cursor string, language string) (*domains.Puzzle, error) {
 	ctx, span := p.tracer.Start(ctx, "GetPreviousForRecent", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
 		semconv.DBSystemPostgreSQL,
 	))
@@ -335,6 +418,7 @@ func (p *puzzle) GetPreviousForRecent(ctx context.Context, cursor string) (*doma
 		NewSelect().
 		Model(&puzzle).
 		Where("puzzle.created_at > ?", cursor).
+		Where("puzzle.hidden_at IS NULL").
 		OrderExpr("puzzle.created_at ASC").
 		Limit(1)
 
@@ -347,6 +431,10 @@ func (p *puzzle) GetPreviousForRecent(ctx context.Context, cursor string) (*doma
 			Where("game.id IS NULL")
 	}
 
+	if language != "" {
+		query = query.Where("puzzle.language = ?", language)
+	}
+
 	err := query.Scan(ctx)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		span.SetStatus(codes.Error, "")
@@ -361,6 +449,162 @@ func (p *puzzle) GetPreviousForRecent(ctx context.Context, cursor string) (*doma
 	return &puzzle, nil
 }
 
+func (p *puzzle) GetTagged(ctx context.Context, slug string, opts domains.PuzzleCursorPaginationOpts) ([]domains.PuzzleSummary, error) {
+	ctx, span := p.tracer.Start(ctx, "GetTagged", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	session := domains.SessionFromContext(ctx)
+
+	var puzzles []domains.PuzzleSummary
+	query := p.db.
+		NewSelect().
+		Model(&puzzles).
+		Column("puzzle_summary.id", "puzzle_summary.difficulty", "puzzle_summary.max_attempts", "puzzle_summary.group_count", "puzzle_summary.group_size", "puzzle_summary.language", "puzzle_summary.created_at", "puzzle_summary.updated_at", "puzzle_summary.user_id").
+		ColumnExpr("(?) AS num_of_likes", p.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = puzzle_summary.id AND active = TRUE")).
+		Relation("CreatedBy", withDeletedUsers).
+		Where("puzzle_summary.deleted_at IS NULL").
+		Join("JOIN puzzle_tags AS puzzle_tag").JoinOn("puzzle_tag.puzzle_id = puzzle_summary.id").
+		Join("JOIN tags AS tag").JoinOn("tag.id = puzzle_tag.tag_id").
+		Where("tag.slug = ?", slug).
+		Where("puzzle_summary.hidden_at IS NULL").
+		Group("puzzle_summary.id", "created_by.id").
+		OrderExpr("puzzle_summary.created_at DESC").
+		Limit(opts.Limit + 1)
+
+	if session != nil && session.IsAuthenticated() {
+		query = query.
+			ColumnExpr("(?) AS me_liked_at", p.db.NewRaw("SELECT updated_at FROM puzzle_likes WHERE puzzle_id = puzzle_summary.id AND active = TRUE AND user_id = ?", session.UserID.String))
+	}
+
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := opts.Cursor.Decode()
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		query = query.Where("puzzle_summary.created_at <= ?", decoded)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if err := attachSummaryTags(ctx, p.db, puzzles); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return puzzles, nil
+}
+
+func (p *puzzle) GetVersions(ctx context.Context, id string) ([]domains.PuzzleVersion, error) {
+	ctx, span := p.tracer.Start(ctx, "GetVersions", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	versions := make([]domains.PuzzleVersion, 0)
+	if err := p.db.NewSelect().
+		Model(&versions).
+		Where("puzzle_id = ?", id).
+		Order("version ASC").
+		Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (p *puzzle) Search(ctx context.Context, opts domains.PuzzleSearchOpts) ([]domains.PuzzleSummary, error) {
+	ctx, span := p.tracer.Start(ctx, "Search", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	session := domains.SessionFromContext(ctx)
+
+	// Matches either stemmed English words or the words as they were written, since search documents hold both, so that
+	// puzzles in other languages can be found
+	tsquery := p.db.NewRaw("(websearch_to_tsquery('english', ?) || websearch_to_tsquery('simple', ?))", opts.Query, opts.Query)
+	// Rounded so that the rank can be encoded into the cursor without losing precision
+	rank := p.db.NewRaw("ROUND(ts_rank(puzzle_summary.search, ?)::NUMERIC, 6)", tsquery)
+
+	var puzzles []domains.PuzzleSummary
+	query := p.db.
+		NewSelect().
+		Model(&puzzles).
+		Column("puzzle_summary.id", "puzzle_summary.difficulty", "puzzle_summary.max_attempts", "puzzle_summary.group_count", "puzzle_summary.group_size", "puzzle_summary.language", "puzzle_summary.created_at", "puzzle_summary.updated_at", "puzzle_summary.user_id").
+		ColumnExpr("(?) AS num_of_likes", p.db.NewRaw("SELECT COUNT(id) FROM puzzle_likes WHERE puzzle_id = puzzle_summary.id AND active = TRUE")).
+		ColumnExpr("? AS rank", rank).
+		Relation("CreatedBy", withDeletedUsers).
+		Where("puzzle_summary.deleted_at IS NULL").
+		Where("puzzle_summary.search @@ ?", tsquery).
+		Where("puzzle_summary.hidden_at IS NULL").
+		OrderExpr("rank DESC, puzzle_summary.created_at DESC").
+		Limit(opts.Limit + 1)
+
+	if opts.Difficulty != "" {
+		query = query.Where("puzzle_summary.difficulty = ?", opts.Difficulty)
+	}
+	if opts.Language != "" {
+		query = query.Where("puzzle_summary.language = ?", opts.Language)
+	}
+	if opts.UserID != "" {
+		query = query.Where("puzzle_summary.user_id = ?", opts.UserID)
+	}
+
+	// - Check whether the user has liked the puzzle
+	// - Filter out puzzles that the user has already played, if requested
+	if session != nil && session.IsAuthenticated() {
+		query = query.
+			ColumnExpr("(?) AS me_liked_at", p.db.NewRaw("SELECT updated_at FROM puzzle_likes WHERE puzzle_id = puzzle_summary.id AND active = TRUE AND user_id = ?", session.UserID.String))
+
+		if opts.Unplayed {
+			query = query.
+				Where("NOT EXISTS (?)", p.db.NewRaw("SELECT 1 FROM games WHERE games.puzzle_id = puzzle_summary.id AND games.user_id = ? AND games.completed_at IS NOT NULL", session.UserID.String))
+		}
+	}
+
+	if !opts.Cursor.IsEmpty() {
+		decodedRank, decodedCreatedAt, err := opts.DecodeCursor()
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		query = query.Where("(?, puzzle_summary.created_at) <= (?, ?)", rank, decodedRank, decodedCreatedAt)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if err := attachSummaryTags(ctx, p.db, puzzles); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return puzzles, nil
+}
+
 func (p *puzzle) ToggleLike(ctx context.Context, id string) (*domains.PuzzleLike, error) {
 	ctx, span := p.tracer.Start(ctx, "ToggleLike", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
 		semconv.DBSystemPostgreSQL,
@@ -393,3 +637,222 @@ func (p *puzzle) ToggleLike(ctx context.Context, id string) (*domains.PuzzleLike
 
 	return &like, nil
 }
+
+func (p *puzzle) Update(ctx context.Context, payload domains.Puzzle) (*domains.Puzzle, error) {
+	ctx, span := p.tracer.Start(ctx, "Update", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	err := p.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Only update the puzzle if it hasn't been changed since the caller loaded it
+		res, err := tx.NewUpdate().
+			Model(&payload).
+			Column("difficulty", "updated_at", "version").
+			WherePK().
+			Where("version = ?", payload.Version-1).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return repositories.ErrPuzzleOutdated
+		}
+
+		for i := range payload.Groups {
+			if _, err := tx.NewUpdate().
+				Model(&payload.Groups[i]).
+				Column("description").
+				WherePK().
+				Where("puzzle_id = ?", payload.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Games that were started before this edit stay pinned to the previous version
+		version := domains.NewPuzzleVersion(payload)
+		if _, err := tx.NewInsert().Model(&version).Exec(ctx); err != nil {
+			return err
+		}
+
+		tags, err := saveTags(ctx, tx, payload.ID, payload.Tags)
+		if err != nil {
+			return err
+		}
+		payload.Tags = tags
+
+		return refreshSearch(ctx, tx, payload.ID)
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return &payload, nil
+}
+
+// Rebuilds the search document of the puzzle with the given id. Must be called whenever its groups or blocks change
+func refreshSearch(ctx context.Context, tx bun.Tx, id string) error {
+	_, err := tx.NewUpdate().
+		Model((*domains.Puzzle)(nil)).
+		Set("search = puzzle_search_document(?)", id).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	return err
+}
+
+// Links the puzzle with the given id to the given tags, in place of the tags that it was linked to before. Tags that
+// don't exist yet are created, and, the usage count of every tag that was linked or unlinked is refreshed. Returns the
+// stored tags
+func saveTags(ctx context.Context, tx bun.Tx, id string, tags []domains.Tag) ([]domains.Tag, error) {
+	previous, err := getTagIDs(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.NewDelete().Model((*domains.PuzzleTag)(nil)).Where("puzzle_id = ?", id).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, refreshTagCounts(ctx, tx, previous)
+	}
+
+	// Tags are shared between puzzles so only create the ones that another puzzle hasn't already
+	if _, err := tx.NewInsert().Model(&tags).On("CONFLICT DO NOTHING").Returning("NULL").Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slugs = append(slugs, tag.Slug)
+	}
+
+	var stored []domains.Tag
+	if err := tx.NewSelect().Model(&stored).Where("slug IN (?)", bun.In(slugs)).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	puzzleTags := make([]domains.PuzzleTag, 0, len(stored))
+	for _, tag := range stored {
+		puzzleTags = append(puzzleTags, domains.PuzzleTag{
+			PuzzleID: id,
+			TagID:    tag.ID,
+		})
+		previous = append(previous, tag.ID)
+	}
+	if _, err := tx.NewInsert().Model(&puzzleTags).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := refreshTagCounts(ctx, tx, previous); err != nil {
+		return nil, err
+	}
+
+	tagged, err := getTags(ctx, tx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return tagged[id], nil
+}
+
+// Gets the ids of the tags that the puzzle with the given id is linked to
+func getTagIDs(ctx context.Context, db bun.IDB, id string) ([]string, error) {
+	ids := make([]string, 0)
+	if err := db.NewSelect().
+		Model((*domains.PuzzleTag)(nil)).
+		Column("tag_id").
+		Where("puzzle_id = ?", id).
+		Scan(ctx, &ids); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Gets the tags of the puzzles with the given ids, keyed by the puzzle's id. Tags are ordered by their slug
+func getTags(ctx context.Context, db bun.IDB, ids []string) (map[string][]domains.Tag, error) {
+	tags := make(map[string][]domains.Tag, len(ids))
+	if len(ids) == 0 {
+		return tags, nil
+	}
+
+	var puzzleTags []domains.PuzzleTag
+	if err := db.NewSelect().
+		Model(&puzzleTags).
+		Relation("Tag").
+		Where("puzzle_tag.puzzle_id IN (?)", bun.In(ids)).
+		OrderExpr("tag.slug ASC").
+		Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	for _, puzzleTag := range puzzleTags {
+		tags[puzzleTag.PuzzleID] = append(tags[puzzleTag.PuzzleID], *puzzleTag.Tag)
+	}
+
+	return tags, nil
+}
+
+// Sets the tags of each puzzle
+func attachTags(ctx context.Context, db bun.IDB, puzzles []domains.Puzzle) error {
+	ids := make([]string, 0, len(puzzles))
+	for _, puzzle := range puzzles {
+		ids = append(ids, puzzle.ID)
+	}
+
+	tags, err := getTags(ctx, db, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range puzzles {
+		puzzles[i].Tags = tags[puzzles[i].ID]
+	}
+
+	return nil
+}
+
+// Sets the tags of each puzzle summary
+func attachSummaryTags(ctx context.Context, db bun.IDB, puzzles []domains.PuzzleSummary) error {
+	ids := make([]string, 0, len(puzzles))
+	for _, puzzle := range puzzles {
+		ids = append(ids, puzzle.ID)
+	}
+
+	tags, err := getTags(ctx, db, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range puzzles {
+		puzzles[i].Tags = tags[puzzles[i].ID]
+	}
+
+	return nil
+}
+
+// Recounts the listed puzzles that use each of the tags with the given ids. Must be called whenever a puzzle is linked
+// to, or unlinked from, a tag, or, whenever a tagged puzzle is deleted or hidden
+func refreshTagCounts(ctx context.Context, db bun.IDB, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := db.NewUpdate().
+		Model((*domains.Tag)(nil)).
+		Set("num_of_puzzles = (?)", db.NewRaw("SELECT COUNT(puzzle_tags.puzzle_id) FROM puzzle_tags JOIN puzzles ON puzzles.id = puzzle_tags.puzzle_id WHERE puzzle_tags.tag_id = tag.id AND puzzles.deleted_at IS NULL AND puzzles.hidden_at IS NULL")).
+		Where("tag.id IN (?)", bun.In(ids)).
+		Exec(ctx)
+
+	return err
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Report = (*report)(nil)
+
+type report struct {
+	tracer trace.Tracer
+
+	db *bun.DB
+}
+
+func NewReport(db *bun.DB) repositories.Report {
+	logrus.Info("Created Report Postgres Repository")
+
+	return &report{
+		tracer: telemetry.Tracer("postgres.report"),
+
+		db: db,
+	}
+}
+
+func (r *report) Create(ctx context.Context, payload domains.Report) (*domains.Report, error) {
+	ctx, span := r.tracer.Start(ctx, "Create", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	_, err := r.db.NewInsert().Model(&payload).Exec(ctx)
+	if err != nil && IsUniqueError(err) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrReportDuplicate)
+
+		return nil, repositories.ErrReportDuplicate
+	} else if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return r.Get(ctx, payload.ID)
+}
+
+func (r *report) Get(ctx context.Context, id string) (*domains.Report, error) {
+	ctx, span := r.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var report domains.Report
+	if err := r.db.
+		NewSelect().
+		Model(&report).
+		Relation("ReportedUser", withDeletedUsers).
+		Where("report.id = ?", id).
+		Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (r *report) GetOpen(ctx context.Context, opts domains.ReportCursorPaginationOpts) ([]domains.Report, error) {
+	ctx, span := r.tracer.Start(ctx, "GetOpen", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var reports []domains.Report
+	query := r.db.
+		NewSelect().
+		Model(&reports).
+		Relation("ReportedUser", withDeletedUsers).
+		Where("report.resolved_at IS NULL").
+		OrderExpr("report.created_at ASC").
+		Limit(opts.Limit + 1)
+
+	if !opts.Cursor.IsEmpty() {
+		decoded, err := opts.Cursor.Decode()
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, err
+		}
+
+		query = query.Where("report.created_at >= ?", decoded)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (r *report) Resolve(ctx context.Context, payload domains.Report) (*domains.Report, error) {
+	ctx, span := r.tracer.Start(ctx, "Resolve", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model(&payload).
+			Column("action", "resolved_at", "resolved_by").
+			WherePK().
+			Where("resolved_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return repositories.ErrReportResolved
+		}
+
+		// Close every other open report that the action settles
+		settled := tx.NewUpdate().
+			Model((*domains.Report)(nil)).
+			Set("action = ?", payload.Action).
+			Set("resolved_at = ?", payload.ResolvedAt).
+			Set("resolved_by = ?", payload.ResolvedBy).
+			Where("resolved_at IS NULL")
+
+		switch payload.Action {
+		case "HIDE_PUZZLE":
+			if _, err := tx.NewUpdate().
+				Model((*domains.Puzzle)(nil)).
+				Set("hidden_at = ?", payload.ResolvedAt).
+				Where("id = ?", payload.PuzzleID).
+				Where("hidden_at IS NULL").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Hidden puzzles no longer count towards their tags
+			ids, err := getTagIDs(ctx, tx, payload.PuzzleID)
+			if err != nil {
+				return err
+			}
+			if err := refreshTagCounts(ctx, tx, ids); err != nil {
+				return err
+			}
+
+			settled = settled.Where("puzzle_id = ?", payload.PuzzleID)
+		case "SUSPEND_USER":
+			if _, err := tx.NewUpdate().
+				Model((*domains.User)(nil)).
+				Set("suspended_at = ?", payload.ResolvedAt).
+				Where("id = ?", payload.ReportedUserID).
+				Where("suspended_at IS NULL").
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			settled = settled.Where("reported_user_id = ?", payload.ReportedUserID)
+		default:
+			return nil
+		}
+
+		_, err = settled.Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return r.Get(ctx, payload.ID)
+}
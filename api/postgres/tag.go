@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ repositories.Tag = (*tag)(nil)
+
+type tag struct {
+	tracer trace.Tracer
+
+	db *bun.DB
+}
+
+func NewTag(db *bun.DB) repositories.Tag {
+	logrus.Info("Created Tag Postgres Repository")
+
+	return &tag{
+		tracer: telemetry.Tracer("postgres.tag"),
+
+		db: db,
+	}
+}
+
+func (t *tag) Get(ctx context.Context, slug string) (*domains.Tag, error) {
+	ctx, span := t.tracer.Start(ctx, "Get", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	var found domains.Tag
+	if err := t.db.NewSelect().Model(&found).Where("slug = ?", slug).Scan(ctx); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+func (t *tag) Search(ctx context.Context, opts domains.TagSearchOpts) ([]domains.Tag, error) {
+	ctx, span := t.tracer.Start(ctx, "Search", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	tags := make([]domains.Tag, 0)
+	query := t.db.
+		NewSelect().
+		Model(&tags).
+		Where("tag.num_of_puzzles > 0").
+		OrderExpr("tag.num_of_puzzles DESC, tag.slug ASC").
+		Limit(opts.Limit)
+
+	// Slugs only ever contain letters, numbers, and, dashes so the query doesn't need to be escaped
+	if slug := domains.TagSlug(opts.Query); slug != "" {
+		query = query.Where("tag.slug LIKE ?", slug+"%")
+	}
+
+	if err := query.Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"github.com/RagOfJoes/puzzlely/domains"
 	"github.com/RagOfJoes/puzzlely/internal/telemetry"
@@ -11,6 +13,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 )
 
 var _ repositories.User = (*user)(nil)
@@ -75,6 +78,117 @@ func (u *user) Get(ctx context.Context, id string) (*domains.User, error) {
 	return &user, nil
 }
 
+func (u *user) GetStats(ctx context.Context, id string) (*domains.UserStats, error) {
+	ctx, span := u.tracer.Start(ctx, "GetStats", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	// Every game that the user has played, including games for puzzles that have since been deleted. Games are counted
+	// against the version of the puzzle that they were played on
+	games := u.db.NewSelect().
+		TableExpr("games AS game").
+		Join("JOIN puzzle_versions AS puzzle_version ON puzzle_version.puzzle_id = game.puzzle_id AND puzzle_version.version = game.puzzle_version").
+		ColumnExpr("game.completed_at, puzzle_version.difficulty").
+		ColumnExpr("(?) AS attempts", u.db.NewRaw("SELECT COUNT(DISTINCT(attempt_order)) FROM game_attempts WHERE game_id = game.id")).
+		ColumnExpr("(?) AS correct", u.db.NewRaw("SELECT COUNT(id) FROM game_corrects WHERE game_id = game.id")).
+		ColumnExpr("jsonb_array_length(puzzle_version.groups) AS num_of_groups").
+		Where("game.user_id = ?", id)
+
+	stats := domains.UserStats{}
+
+	eg := errgroup.Group{}
+	eg.Go(func() error {
+		return u.db.NewSelect().
+			With("user_games", games).
+			TableExpr("user_games").
+			ColumnExpr("COUNT(*)").
+			ColumnExpr("COUNT(completed_at)").
+			ColumnExpr("COUNT(*) FILTER (WHERE completed_at IS NOT NULL AND correct = num_of_groups AND attempts = correct)").
+			ColumnExpr("COALESCE(AVG(attempts) FILTER (WHERE completed_at IS NOT NULL), 0)").
+			Scan(ctx, &stats.GamesPlayed, &stats.GamesCompleted, &stats.PerfectSolves, &stats.AverageAttempts)
+	})
+
+	var difficulties []struct {
+		Difficulty string
+		Completed  int
+		Won        int
+	}
+	eg.Go(func() error {
+		return u.db.NewSelect().
+			With("user_games", games).
+			TableExpr("user_games").
+			ColumnExpr("difficulty").
+			ColumnExpr("COUNT(*) AS completed").
+			ColumnExpr("COUNT(*) FILTER (WHERE correct = num_of_groups) AS won").
+			Where("completed_at IS NOT NULL").
+			Group("difficulty").
+			Scan(ctx, &difficulties)
+	})
+
+	var groupsFound []struct {
+		Correct int
+		Count   int
+	}
+	eg.Go(func() error {
+		return u.db.NewSelect().
+			With("user_games", games).
+			TableExpr("user_games").
+			ColumnExpr("correct").
+			ColumnExpr("COUNT(*) AS count").
+			Where("completed_at IS NOT NULL").
+			Group("correct").
+			Scan(ctx, &groupsFound)
+	})
+
+	eg.Go(func() error {
+		return u.db.NewSelect().
+			Model((*domains.Puzzle)(nil)).
+			ColumnExpr("COUNT(puzzle.id)").
+			ColumnExpr("(?)", u.db.NewRaw("SELECT COUNT(puzzle_likes.id) FROM puzzle_likes JOIN puzzles ON puzzles.id = puzzle_likes.puzzle_id WHERE puzzles.user_id = ? AND puzzles.deleted_at IS NULL AND puzzle_likes.active = TRUE", id)).
+			Where("puzzle.user_id = ?", id).
+			Scan(ctx, &stats.PuzzlesCreated, &stats.LikesReceived)
+	})
+
+	if err := eg.Wait(); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	stats.Difficulties = make([]domains.UserStatsDifficulty, 0)
+	for _, difficulty := range difficulties {
+		stats.Difficulties = append(stats.Difficulties, domains.NewUserStatsDifficulty(difficulty.Difficulty, difficulty.Completed, difficulty.Won))
+	}
+	stats.SortDifficulties()
+
+	counts := make(map[int]int, 0)
+	for _, found := range groupsFound {
+		counts[found.Correct] = found.Count
+	}
+	stats.GroupsFound = domains.NewUserStatsGroupsFound(counts)
+
+	return &stats, nil
+}
+
+func (u *user) GetStreak(ctx context.Context, id string) (*domains.UserStreak, error) {
+	ctx, span := u.tracer.Start(ctx, "GetStreak", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	streak := domains.NewUserStreak(id)
+	if err := u.db.NewSelect().Model(&streak).Where("user_id = ?", id).Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return &streak, nil
+}
+
 func (u *user) GetWithConnection(ctx context.Context, payload domains.Connection) (*domains.User, error) {
 	ctx, span := u.tracer.Start(ctx, "GetWithConnection", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
 		semconv.DBSystemPostgreSQL,
@@ -134,5 +248,53 @@ func (u *user) Update(ctx context.Context, payload domains.User) (*domains.User,
 }
 
 func (u *user) Delete(ctx context.Context, id string) error {
-	panic("unimplemented")
+	ctx, span := u.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+	))
+	defer span.End()
+
+	err := u.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var user domains.User
+		if err := tx.NewSelect().
+			Model(&user).
+			Where("id = ?", id).
+			For("UPDATE").
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		if _, err := tx.NewDelete().Model((*domains.Session)(nil)).Where("user_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.NewDelete().Model((*domains.Connection)(nil)).Where("user_id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+
+		// Keep the row so that the user's puzzles and games remain intact, but, strip it of anything identifiable
+		user.Anonymize()
+		if _, err := tx.NewUpdate().
+			Model(&user).
+			Column("username", "updated_at", "deleted_at").
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return err
+	}
+
+	return nil
+}
+
+// Includes users that have deleted their account, as their anonymized selves, when they're joined as a relation. bun
+// can only include soft deleted rows for the entire query, so, any other soft deleted model of the query must be
+// filtered out explicitly
+func withDeletedUsers(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.WhereAllWithDeleted()
 }
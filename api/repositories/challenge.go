@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+)
+
+// Errors
+var (
+	ErrChallengeAccepted = errors.New("Challenge has already been accepted.")
+)
+
+// Challenge defines methods for a challenge repository
+type Challenge interface {
+	// Create creates a new challenge
+	Create(ctx context.Context, payload domains.Challenge) (*domains.Challenge, error)
+
+	// Get gets the challenge with the given id
+	Get(ctx context.Context, id string) (*domains.Challenge, error)
+	// GetForUser gets the challenges that the given user has either created or accepted, newest first
+	GetForUser(ctx context.Context, id string, opts domains.ChallengeCursorPaginationOpts) ([]domains.Challenge, error)
+
+	// Accept sets `payload.OpponentID` as the challenge's opponent. Returns `ErrChallengeAccepted` if another user has
+	// already accepted it
+	Accept(ctx context.Context, payload domains.Challenge) (*domains.Challenge, error)
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+)
+
+// Errors
+var (
+	ErrDailyPuzzleNotAvailable = errors.New("Either the date or the puzzle has already been scheduled.")
+)
+
+// DailyPuzzle defines methods for a daily puzzle repository. Dates are formatted with `domains.DailyPuzzleDateLayout`
+type DailyPuzzle interface {
+	// Create schedules a puzzle for a date. Returns `ErrDailyPuzzleNotAvailable` if either the date or the puzzle has
+	// already been scheduled
+	Create(ctx context.Context, payload domains.DailyPuzzle) (*domains.DailyPuzzle, error)
+
+	// Delete unschedules the puzzle for the given date
+	Delete(ctx context.Context, date string) error
+
+	// Get gets the schedule for the given date
+	Get(ctx context.Context, date string) (*domains.DailyPuzzle, error)
+	// GetLatest gets the schedule with the latest date, if any
+	GetLatest(ctx context.Context) (*domains.DailyPuzzle, error)
+	// GetPast gets the schedules before the given date, most recent first. Deleted puzzles are excluded
+	GetPast(ctx context.Context, date string, opts domains.PuzzleCursorPaginationOpts) ([]domains.DailyPuzzle, error)
+	// GetUpcoming gets the schedules on or after the given date, soonest first
+	GetUpcoming(ctx context.Context, date string, limit int) ([]domains.DailyPuzzle, error)
+}
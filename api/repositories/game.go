@@ -2,16 +2,35 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/RagOfJoes/puzzlely/domains"
 )
 
+// Errors
+var (
+	ErrGameOutdated = errors.New("Game has been changed since it was loaded. Reload it and try again.")
+)
+
 type Game interface {
+	// Get gets the game with the given id, regardless of who it belongs to
+	Get(ctx context.Context, id string) (*domains.Game, error)
+	// GetCorrect gets the groups that the currently authenticated user has solved for each of the given puzzles. The
+	// result is keyed by puzzle id and each entry is in the order that the groups were solved
+	GetCorrect(ctx context.Context, ids []string) (map[string][]string, error)
 	// GetHistory gets the history of the given user
 	GetHistory(ctx context.Context, id string, opts domains.GameCursorPaginationOpts) ([]domains.GameSummary, error)
+	// GetLeaderboard gets the ranked, completed games for the given puzzle
+	GetLeaderboard(ctx context.Context, id string, opts domains.LeaderboardOpts) ([]domains.LeaderboardEntry, error)
+	// GetLeaderboardEntry gets the given user's ranked game for the given puzzle, if they've completed it
+	GetLeaderboardEntry(ctx context.Context, id string, userID string) (*domains.LeaderboardEntry, error)
+	// GetLeaderboardEntries gets the ranked games of the given users for each of the given puzzles that they've completed.
+	// Each game is ranked on its own puzzle's leaderboard
+	GetLeaderboardEntries(ctx context.Context, ids []string, userIDs []string) ([]domains.LeaderboardEntry, error)
 	// GetWithPuzzleID gets the game with the given puzzle id
 	GetWithPuzzleID(ctx context.Context, id string) (*domains.Game, error)
 
-	// Save saves a game
+	// Save saves a game. When the game is completed for the first time, the user's streaks are updated using the user's
+	// timezone. `ErrGameOutdated` is returned if the game has been saved since the payload's revision was loaded
 	Save(ctx context.Context, payload domains.Game) (*domains.Game, error)
 }
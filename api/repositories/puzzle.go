@@ -2,14 +2,26 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/RagOfJoes/puzzlely/domains"
 )
 
+// Errors
+var (
+	ErrPuzzleOutdated = errors.New("Puzzle has been changed since it was loaded. Reload it and try again.")
+)
+
+// Puzzle defines methods for a puzzle repository. Puzzles that have been hidden by a moderator are left out of every
+// listing but can still be retrieved with `Get`
 type Puzzle interface {
-	// Create creates a new puzzle
+	// Create creates a new puzzle along with its first version. Tags that don't exist yet are created and the usage
+	// count of every tag is updated
 	Create(ctx context.Context, payload domains.Puzzle) (*domains.Puzzle, error)
 
+	// Delete soft deletes the puzzle with the given id
+	Delete(ctx context.Context, id string) error
+
 	// Get gets the puzzle with the given id
 	Get(ctx context.Context, id string) (*domains.Puzzle, error)
 	// GetCreated gets the puzzles created by the given user
@@ -18,11 +30,22 @@ type Puzzle interface {
 	GetLiked(ctx context.Context, id string, opts domains.PuzzleCursorPaginationOpts) ([]domains.PuzzleSummary, error)
 	// GetRecent gets the recent puzzles
 	GetRecent(ctx context.Context, opts domains.PuzzleCursorPaginationOpts) ([]domains.Puzzle, error)
-	// GetNextForRecent gets the potential next puzzle for `GetRecent`
-	GetNextForRecent(ctx context.Context, cursor string) (*domains.Puzzle, error)
-	// GetPreviousForRecent gets the potential previous for `GetRecent`
-	GetPreviousForRecent(ctx context.Context, cursor string) (*domains.Puzzle, error)
+	// GetNextForRecent gets the potential next puzzle for `GetRecent`. An empty language includes puzzles in any language
+	GetNextForRecent(ctx context.Context, cursor string, language string) (*domains.Puzzle, error)
+	// GetPreviousForRecent gets the potential previous for `GetRecent`. An empty language includes puzzles in any language
+	GetPreviousForRecent(ctx context.Context, cursor string, language string) (*domains.Puzzle, error)
+	// GetTagged gets the puzzles that have the tag with the given slug
+	GetTagged(ctx context.Context, slug string, opts domains.PuzzleCursorPaginationOpts) ([]domains.PuzzleSummary, error)
+	// GetVersions gets every version of the given puzzle, from oldest to newest
+	GetVersions(ctx context.Context, id string) ([]domains.PuzzleVersion, error)
+
+	// Search searches puzzles by their group descriptions and block values. Results are ordered by rank
+	Search(ctx context.Context, opts domains.PuzzleSearchOpts) ([]domains.PuzzleSummary, error)
 
 	// ToggleLike likes a puzzle with the given id
 	ToggleLike(ctx context.Context, id string) (*domains.PuzzleLike, error)
+
+	// Update updates a puzzle's difficulty, group descriptions, and, tags, and records the result as a new version.
+	// `payload.Version` must directly follow the stored version, otherwise `ErrPuzzleOutdated` is returned
+	Update(ctx context.Context, payload domains.Puzzle) (*domains.Puzzle, error)
 }
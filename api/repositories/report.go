@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+)
+
+// Errors
+var (
+	ErrReportDuplicate = errors.New("You've already reported this. A moderator will review it soon.")
+	ErrReportResolved  = errors.New("Report has already been resolved.")
+)
+
+// Report defines methods for a report repository
+type Report interface {
+	// Create files a new report. Returns `ErrReportDuplicate` if the reporter already has an open report against the same
+	// puzzle, or user
+	Create(ctx context.Context, payload domains.Report) (*domains.Report, error)
+
+	// Get gets the report with the given id
+	Get(ctx context.Context, id string) (*domains.Report, error)
+	// GetOpen gets the reports that are still waiting on a moderator, oldest first
+	GetOpen(ctx context.Context, opts domains.ReportCursorPaginationOpts) ([]domains.Report, error)
+
+	// Resolve closes a report with `payload.Action` and applies it. Every other open report that the action settles, i.e.,
+	// reports against the same hidden puzzle or suspended user, is closed along with it. Returns `ErrReportResolved` if
+	// the report has already been closed
+	Resolve(ctx context.Context, payload domains.Report) (*domains.Report, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+)
+
+// Tag defines methods for a tag repository. Tags are created and counted by the puzzle repository
+type Tag interface {
+	// Get gets the tag with the given slug
+	Get(ctx context.Context, slug string) (*domains.Tag, error)
+
+	// Search gets the tags, that are used by at least one puzzle, whose slug starts with the query. Results are ordered
+	// by the number of puzzles that use them
+	Search(ctx context.Context, opts domains.TagSearchOpts) ([]domains.Tag, error)
+}
@@ -19,12 +19,17 @@ type User interface {
 
 	// Get retrieves a user with their id
 	Get(ctx context.Context, id string) (*domains.User, error)
+	// GetStats retrieves the aggregated games and puzzles of a user
+	GetStats(ctx context.Context, id string) (*domains.UserStats, error)
+	// GetStreak retrieves the play and win streaks of a user. A user that has never completed a game gets empty streaks
+	GetStreak(ctx context.Context, id string) (*domains.UserStreak, error)
 	// GetWithConnection retrieves a user with one of their connection
 	GetWithConnection(ctx context.Context, payload domains.Connection) (*domains.User, error)
 
 	// Update updates a user
 	Update(ctx context.Context, payload domains.User) (*domains.User, error)
 
-	// Delete deletes a user
+	// Delete soft deletes a user, anonymizes their username, and removes their connections and sessions. Puzzles and games
+	// created by the user are kept and will show the anonymized username
 	Delete(ctx context.Context, id string) error
 }
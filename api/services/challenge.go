@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrChallengeAccept       = errors.New("Failed to accept challenge.")
+	ErrChallengeCompleted    = errors.New("You've already completed this puzzle.")
+	ErrChallengeCreate       = errors.New("Failed to create challenge.")
+	ErrChallengeList         = errors.New("Failed to get challenges.")
+	ErrChallengeNotCompleted = errors.New("Puzzle must be completed before others can be challenged.")
+	ErrChallengeNotFound     = errors.New("Challenge not found.")
+	ErrChallengeSelf         = errors.New("You can't accept your own challenge.")
+)
+
+type Challenge struct {
+	tracer trace.Tracer
+
+	game       repositories.Game
+	repository repositories.Challenge
+}
+
+type ChallengeDependencies struct {
+	Game       repositories.Game
+	Repository repositories.Challenge
+}
+
+func NewChallenge(d ChallengeDependencies) Challenge {
+	logrus.Print("Created Challenge Service")
+
+	return Challenge{
+		tracer: telemetry.Tracer("services.challenge"),
+
+		game:       d.Game,
+		repository: d.Repository,
+	}
+}
+
+// New creates a challenge from the currently authenticated user's completed game on the given puzzle
+func (c *Challenge) New(ctx context.Context, payload domains.ChallengePayload) (*domains.Challenge, error) {
+	ctx, span := c.tracer.Start(ctx, "New", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrUnauthorized)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized)
+	}
+
+	game, err := c.game.GetWithPuzzleID(ctx, payload.PuzzleID)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrGameNotFound)
+	}
+	if game.CompletedAt.IsZero() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrChallengeNotCompleted)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrChallengeNotCompleted)
+	}
+
+	newChallenge := domains.NewChallenge(*game)
+	if err := newChallenge.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrChallengeCreate)
+	}
+
+	challenge, err := c.repository.Create(ctx, newChallenge)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeCreate)
+	}
+
+	if err := c.compare(ctx, challenge); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeCreate)
+	}
+
+	return challenge, nil
+}
+
+// Find retrieves a challenge along with the results of both participants. Anyone with the link can view a challenge
+// so that they can decide whether to accept it
+func (c *Challenge) Find(ctx context.Context, id ulid.ULID) (*domains.Challenge, error) {
+	ctx, span := c.tracer.Start(ctx, "Find", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	challenge, err := c.repository.Get(ctx, id.String())
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrChallengeNotFound)
+	}
+	if err := challenge.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrChallengeNotFound)
+	}
+
+	if err := c.compare(ctx, challenge); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeNotFound)
+	}
+
+	return challenge, nil
+}
+
+// FindForUser retrieves the challenges that the currently authenticated user has either created or accepted, newest
+// first
+func (c *Challenge) FindForUser(ctx context.Context, opts domains.ChallengeCursorPaginationOpts) (*domains.ChallengeConnection, error) {
+	ctx, span := c.tracer.Start(ctx, "FindForUser", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrUnauthorized)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized)
+	}
+	if err := opts.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrChallengeList)
+	}
+
+	challenges, err := c.repository.GetForUser(ctx, session.User.ID, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeList)
+	}
+
+	page := make([]*domains.Challenge, 0, len(challenges))
+	for i := range challenges {
+		if err := challenges[i].Validate(); err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeList)
+		}
+
+		page = append(page, &challenges[i])
+	}
+	if err := c.compare(ctx, page...); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeList)
+	}
+
+	connection, err := domains.BuildChallengeConnection(challenges, opts.Limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeList)
+	}
+
+	return connection, nil
+}
+
+// Accept makes the currently authenticated user the challenge's opponent. The puzzle is then played as usual and the
+// comparison is filled in once they've completed it
+func (c *Challenge) Accept(ctx context.Context, id ulid.ULID) (*domains.Challenge, error) {
+	ctx, span := c.tracer.Start(ctx, "Accept", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrUnauthorized)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized)
+	}
+
+	challenge, err := c.Find(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if challenge.UserID == session.User.ID {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrChallengeSelf)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrChallengeSelf)
+	}
+	// Accepting a challenge again is a no-op for the opponent
+	if challenge.OpponentID == session.User.ID {
+		return challenge, nil
+	}
+	if challenge.IsAccepted() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(repositories.ErrChallengeAccepted)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", repositories.ErrChallengeAccepted)
+	}
+
+	// The opponent has to play the puzzle after accepting for the comparison to be fair
+	completed, err := c.game.GetLeaderboardEntry(ctx, challenge.PuzzleID, session.User.ID)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeAccept)
+	}
+	if completed != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrChallengeCompleted)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrChallengeCompleted)
+	}
+
+	challenge.OpponentID = session.User.ID
+	challenge.AcceptedAt = bun.NullTime{
+		Time: time.Now(),
+	}
+	if err := challenge.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrChallengeAccept)
+	}
+
+	accepted, err := c.repository.Accept(ctx, *challenge)
+	if err != nil && errors.Is(err, repositories.ErrChallengeAccepted) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", repositories.ErrChallengeAccepted)
+	} else if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeAccept)
+	}
+
+	if err := c.compare(ctx, accepted); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrChallengeAccept)
+	}
+
+	return accepted, nil
+}
+
+// compare loads the leaderboard entries of the participants of every challenge, all at once, and fills in each
+// comparison in place
+func (c *Challenge) compare(ctx context.Context, challenges ...*domains.Challenge) error {
+	ids := make([]string, 0, len(challenges))
+	userIDs := make([]string, 0, len(challenges)*2)
+	for _, challenge := range challenges {
+		if !slices.Contains(ids, challenge.PuzzleID) {
+			ids = append(ids, challenge.PuzzleID)
+		}
+
+		userIDs = append(userIDs, challenge.UserID)
+		if challenge.OpponentID != "" {
+			userIDs = append(userIDs, challenge.OpponentID)
+		}
+	}
+
+	entries, err := c.game.GetLeaderboardEntries(ctx, ids, userIDs)
+	if err != nil {
+		return err
+	}
+
+	// Returns the given user's entry on the given puzzle's leaderboard, if they have one
+	find := func(puzzleID string, userID string) *domains.LeaderboardEntry {
+		for i := range entries {
+			if entries[i].PuzzleID == puzzleID && entries[i].UserID == userID {
+				return &entries[i]
+			}
+		}
+
+		return nil
+	}
+
+	for _, challenge := range challenges {
+		var opponentResult *domains.LeaderboardEntry
+		if challenge.OpponentID != "" {
+			opponentResult = find(challenge.PuzzleID, challenge.OpponentID)
+		}
+
+		challenge.Compare(find(challenge.PuzzleID, challenge.UserID), opponentResult)
+	}
+
+	return nil
+}
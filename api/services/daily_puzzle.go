@@ -0,0 +1,401 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/config"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrDailyPuzzleHidden      = errors.New("Hidden puzzles can't be scheduled.")
+	ErrDailyPuzzleInvalidDate = errors.New("Invalid date provided. Dates must be formatted as YYYY-MM-DD.")
+	ErrDailyPuzzleNotAdmin    = errors.New("You must be an admin to manage daily puzzles.")
+	ErrDailyPuzzleNotFound    = errors.New("Daily puzzle not found.")
+	ErrDailyPuzzlePast        = errors.New("Failed to get past daily puzzles.")
+	ErrDailyPuzzlePastDate    = errors.New("Only upcoming daily puzzles can be modified.")
+	ErrDailyPuzzleSchedule    = errors.New("Failed to schedule daily puzzle.")
+	ErrDailyPuzzleUnschedule  = errors.New("Failed to unschedule daily puzzle.")
+	ErrDailyPuzzleUpcoming    = errors.New("Failed to get upcoming daily puzzles.")
+)
+
+const (
+	// dailyPuzzleUpcomingLimit is the max number of upcoming daily puzzles that are returned
+	dailyPuzzleUpcomingLimit = 100
+)
+
+type DailyPuzzle struct {
+	tracer trace.Tracer
+
+	location *time.Location
+
+	game       repositories.Game
+	puzzle     repositories.Puzzle
+	repository repositories.DailyPuzzle
+}
+
+type DailyPuzzleDependencies struct {
+	Config config.Configuration
+
+	Game       repositories.Game
+	Puzzle     repositories.Puzzle
+	Repository repositories.DailyPuzzle
+}
+
+func NewDailyPuzzle(d DailyPuzzleDependencies) DailyPuzzle {
+	logrus.Print("Created Daily Puzzle Service")
+
+	return DailyPuzzle{
+		tracer: telemetry.Tracer("services.daily_puzzle"),
+
+		location: d.Config.Daily.Location(),
+
+		game:       d.Game,
+		puzzle:     d.Puzzle,
+		repository: d.Repository,
+	}
+}
+
+// Today returns the current date in the configured timezone
+func (d *DailyPuzzle) Today() string {
+	return time.Now().In(d.location).Format(domains.DailyPuzzleDateLayout)
+}
+
+// Find retrieves the player-facing representation of the puzzle of the day for the given date. If date is empty then
+// today's puzzle is retrieved. Upcoming daily puzzles are never revealed
+func (d *DailyPuzzle) Find(ctx context.Context, date string) (*domains.PlayerPuzzle, error) {
+	ctx, span := d.tracer.Start(ctx, "Find", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	today := d.Today()
+	if date == "" {
+		date = today
+	}
+	if _, err := time.Parse(domains.DailyPuzzleDateLayout, date); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzleInvalidDate)
+	}
+	if date > today {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrDailyPuzzleNotFound)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeNotFound, "%v", ErrDailyPuzzleNotFound)
+	}
+
+	dailyPuzzle, err := d.repository.Get(ctx, date)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrDailyPuzzleNotFound)
+	}
+
+	puzzle, err := d.puzzle.Get(ctx, dailyPuzzle.PuzzleID)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrDailyPuzzleNotFound)
+	}
+	dailyPuzzle.Puzzle = *puzzle
+
+	if err := dailyPuzzle.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrDailyPuzzleNotFound)
+	}
+	if err := dailyPuzzle.Puzzle.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrDailyPuzzleNotFound)
+	}
+	if !dailyPuzzle.Puzzle.IsVisibleTo(sessionUser(ctx)) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrDailyPuzzleNotFound)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeNotFound, "%v", ErrDailyPuzzleNotFound)
+	}
+
+	correct, err := d.game.GetCorrect(ctx, []string{dailyPuzzle.PuzzleID})
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzleNotFound)
+	}
+
+	playerPuzzle := domains.NewPlayerPuzzle(dailyPuzzle.Puzzle, correct[dailyPuzzle.PuzzleID])
+	if err := playerPuzzle.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzleNotFound)
+	}
+
+	return &playerPuzzle, nil
+}
+
+// FindPast retrieves the player-facing representation of the daily puzzles before today, most recent first
+func (d *DailyPuzzle) FindPast(ctx context.Context, opts domains.PuzzleCursorPaginationOpts) (*domains.PlayerPuzzleConnection, error) {
+	ctx, span := d.tracer.Start(ctx, "FindPast", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := opts.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzlePast)
+	}
+
+	dailyPuzzles, err := d.repository.GetPast(ctx, d.Today(), opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzlePast)
+	}
+	if err := d.withPuzzles(ctx, dailyPuzzles); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzlePast)
+	}
+
+	connection, err := domains.BuildPuzzleConnectionForDaily(dailyPuzzles, opts.Limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzlePast)
+	}
+
+	ids := make([]string, 0)
+	for _, dailyPuzzle := range dailyPuzzles {
+		ids = append(ids, dailyPuzzle.PuzzleID)
+	}
+
+	correct, err := d.game.GetCorrect(ctx, ids)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzlePast)
+	}
+
+	playerConnection, err := domains.BuildPlayerPuzzleConnection(*connection, correct)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzlePast)
+	}
+
+	return playerConnection, nil
+}
+
+// FindUpcoming retrieves the daily puzzles from today onwards, soonest first. Only admins can see upcoming daily puzzles
+func (d *DailyPuzzle) FindUpcoming(ctx context.Context) ([]domains.DailyPuzzle, error) {
+	ctx, span := d.tracer.Start(ctx, "FindUpcoming", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := authorize(ctx, domains.PermissionManageDaily, ErrDailyPuzzleNotAdmin); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	dailyPuzzles, err := d.repository.GetUpcoming(ctx, d.Today(), dailyPuzzleUpcomingLimit)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzleUpcoming)
+	}
+	if err := d.withPuzzles(ctx, dailyPuzzles); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzleUpcoming)
+	}
+
+	return dailyPuzzles, nil
+}
+
+// Schedule queues a puzzle as the puzzle of the day. If a date isn't provided then the puzzle is scheduled for the day
+// after the last scheduled puzzle, or today if nothing has been scheduled from today onwards
+func (d *DailyPuzzle) Schedule(ctx context.Context, payload domains.DailyPuzzlePayload) (*domains.DailyPuzzle, error) {
+	ctx, span := d.tracer.Start(ctx, "Schedule", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := authorize(ctx, domains.PermissionManageDaily, ErrDailyPuzzleNotAdmin); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	today := d.Today()
+
+	date := payload.Date
+	if date == "" {
+		latest, err := d.repository.GetLatest(ctx)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzleSchedule)
+		}
+
+		date = today
+		if latest != nil {
+			if next := latest.Date.AddDate(0, 0, 1).Format(domains.DailyPuzzleDateLayout); next > date {
+				date = next
+			}
+		}
+	}
+	if date < today {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrDailyPuzzlePastDate)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzlePastDate)
+	}
+
+	puzzle, err := d.puzzle.Get(ctx, payload.PuzzleID)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrPuzzleNotFound)
+	}
+	if !puzzle.HiddenAt.IsZero() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrDailyPuzzleHidden)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzleHidden)
+	}
+
+	parsed, err := time.Parse(domains.DailyPuzzleDateLayout, date)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzleInvalidDate)
+	}
+
+	session := domains.SessionFromContext(ctx)
+	newDailyPuzzle := domains.DailyPuzzle{
+		Date: parsed,
+
+		CreatedAt: time.Now(),
+
+		PuzzleID: payload.PuzzleID,
+		UserID:   session.User.ID,
+	}
+	if err := newDailyPuzzle.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzleSchedule)
+	}
+
+	created, err := d.repository.Create(ctx, newDailyPuzzle)
+	if errors.Is(err, repositories.ErrDailyPuzzleNotAvailable) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err)
+	} else if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzleSchedule)
+	}
+	if err := created.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzleSchedule)
+	}
+
+	return created, nil
+}
+
+// Unschedule removes an upcoming daily puzzle. Daily puzzles that have already been played can't be removed
+func (d *DailyPuzzle) Unschedule(ctx context.Context, date string) error {
+	ctx, span := d.tracer.Start(ctx, "Unschedule", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := authorize(ctx, domains.PermissionManageDaily, ErrDailyPuzzleNotAdmin); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return err
+	}
+	if _, err := time.Parse(domains.DailyPuzzleDateLayout, date); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzleInvalidDate)
+	}
+	if date <= d.Today() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrDailyPuzzlePastDate)
+
+		return internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrDailyPuzzlePastDate)
+	}
+
+	if _, err := d.repository.Get(ctx, date); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrDailyPuzzleNotFound)
+	}
+	if err := d.repository.Delete(ctx, date); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrDailyPuzzleUnschedule)
+	}
+
+	return nil
+}
+
+// withPuzzles loads the puzzle for each daily puzzle in place
+func (d *DailyPuzzle) withPuzzles(ctx context.Context, dailyPuzzles []domains.DailyPuzzle) error {
+	for i := range dailyPuzzles {
+		puzzle, err := d.puzzle.Get(ctx, dailyPuzzles[i].PuzzleID)
+		if err != nil {
+			return err
+		}
+
+		dailyPuzzles[i].Puzzle = *puzzle
+		if err := dailyPuzzles[i].Validate(); err != nil {
+			return err
+		}
+		if err := dailyPuzzles[i].Puzzle.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
 	"github.com/RagOfJoes/puzzlely/domains"
@@ -17,7 +18,9 @@ import (
 // Errors
 var (
 	ErrGameFailedCreate = errors.New("Failed to create a new game.")
+	ErrGameFind         = errors.New("Failed to get game.")
 	ErrGameHistory      = errors.New("Failed to get game history.")
+	ErrGameNotCompleted = errors.New("Game must be completed before it can be shared.")
 	ErrGameNotFound     = errors.New("Game not found.")
 )
 
@@ -41,22 +44,50 @@ func NewGame(d GameDependencies) Game {
 	}
 }
 
+// Find retrieves a game with its id, regardless of who it belongs to
+func (g *Game) Find(ctx context.Context, id ulid.ULID) (*domains.Game, error) {
+	ctx, span := g.tracer.Start(ctx, "Find", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	game, err := g.repository.Get(ctx, id.String())
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrGameNotFound)
+	}
+	if err := game.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrGameNotFound)
+	}
+
+	return game, nil
+}
+
+// FindByPuzzleID retrieves the current user's game for the given puzzle
 func (g *Game) FindByPuzzleID(ctx context.Context, id ulid.ULID) (*domains.Game, error) {
 	ctx, span := g.tracer.Start(ctx, "FindByPuzzleID", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
 
 	game, err := g.repository.GetWithPuzzleID(ctx, id.String())
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
 		span.SetStatus(codes.Error, "")
 		span.RecordError(err)
 
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrGameNotFound)
+	} else if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrGameFind)
 	}
 	if err := game.Validate(); err != nil {
 		span.SetStatus(codes.Error, "")
 		span.RecordError(err)
 
-		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrGameNotFound)
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrGameFind)
 	}
 
 	return game, nil
@@ -84,8 +115,6 @@ func (g *Game) FindHistory(ctx context.Context, id string, opts domains.GameCurs
 	// Validate results
 	for i := range games {
 		game := &games[i]
-		game.Attempts = game.Attempts - int16(game.Score)
-
 		if err := game.Validate(); err != nil {
 			span.SetStatus(codes.Error, "")
 			span.RecordError(err)
@@ -105,6 +134,104 @@ func (g *Game) FindHistory(ctx context.Context, id string, opts domains.GameCurs
 	return connection, nil
 }
 
+// Start retrieves the current user's game for the given puzzle. If the user hasn't played the puzzle yet then a new game,
+// that hasn't been saved, is created for them. Any other error is returned so that a saved game is never replaced
+//
+// NOTE: `puzzle` must be loaded with its groups and blocks
+func (g *Game) Start(ctx context.Context, puzzle domains.Puzzle, user domains.User) (*domains.Game, error) {
+	ctx, span := g.tracer.Start(ctx, "Start", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	id, err := ulid.Parse(puzzle.ID)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrPuzzleNotFound)
+	}
+
+	game, err := g.FindByPuzzleID(ctx, id)
+	if err == nil {
+		return game, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	newGame := domains.NewGame()
+	// Append puzzle
+	newGame.PuzzleID = puzzle.ID
+	newGame.Puzzle = puzzle
+	// Append user
+	newGame.UserID = user.ID
+	newGame.User = user
+
+	return &newGame, nil
+}
+
+// Guess applies the given blocks as the next attempt of the game and saves the result
+func (g *Game) Guess(ctx context.Context, game domains.Game, payload domains.GameGuessPayload) (*domains.Game, error) {
+	ctx, span := g.tracer.Start(ctx, "Guess", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	if err := game.Guess(payload.Blocks); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	saved, err := g.Save(ctx, game)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// Hint reveals a hint of the given type in the game and saves the result
+func (g *Game) Hint(ctx context.Context, game domains.Game, payload domains.GameHintPayload) (*domains.Game, error) {
+	ctx, span := g.tracer.Start(ctx, "Hint", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	if _, err := game.Hint(payload.Type); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	saved, err := g.Save(ctx, game)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return saved, nil
+}
+
 func (g *Game) Save(ctx context.Context, payload domains.Game) (*domains.Game, error) {
 	ctx, span := g.tracer.Start(ctx, "Save", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
@@ -117,7 +244,12 @@ func (g *Game) Save(ctx context.Context, payload domains.Game) (*domains.Game, e
 	}
 
 	game, err := g.repository.Save(ctx, payload)
-	if err != nil {
+	if err != nil && errors.Is(err, repositories.ErrGameOutdated) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", repositories.ErrGameOutdated)
+	} else if err != nil {
 		span.SetStatus(codes.Error, "")
 		span.RecordError(err)
 
@@ -126,3 +258,33 @@ func (g *Game) Save(ctx context.Context, payload domains.Game) (*domains.Game, e
 
 	return game, nil
 }
+
+// Share builds the spoiler-free summary of the current user's completed game for the given puzzle
+func (g *Game) Share(ctx context.Context, id ulid.ULID) (*domains.GameShare, error) {
+	ctx, span := g.tracer.Start(ctx, "Share", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	game, err := g.FindByPuzzleID(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if game.CompletedAt.IsZero() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrGameNotCompleted)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrGameNotCompleted)
+	}
+
+	share := domains.NewGameShare(*game)
+	if err := share.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrGameNotFound)
+	}
+
+	return &share, nil
+}
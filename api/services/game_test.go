@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/memory"
+	"github.com/oklog/ulid/v2"
+)
+
+// Creates a user and returns a context that is authenticated as them
+func authenticate(t *testing.T, db *memory.DB) (context.Context, domains.User) {
+	t.Helper()
+
+	user := domains.NewUser()
+	created, err := memory.NewUser(db).Create(context.Background(), domains.NewConnection("github", user.ID, user.ID), user)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	session := domains.NewSession()
+	if err := session.Authenticate(time.Now().Add(time.Hour), *created); err != nil {
+		t.Fatalf("failed to authenticate session: %v", err)
+	}
+
+	return domains.SessionNewContext(context.Background(), session), *created
+}
+
+// Creates a puzzle with 3 groups of 3 blocks for the user that is authenticated in the context
+func createPuzzle(t *testing.T, ctx context.Context, service Puzzle, user domains.User) domains.Puzzle {
+	t.Helper()
+
+	payload := domains.PuzzleCreatePayload{
+		Difficulty:  "EASY",
+		MaxAttempts: 2,
+	}
+	for i := range 3 {
+		group := domains.PuzzleCreatePayloadGroup{
+			Description: fmt.Sprintf("Group %d", i),
+		}
+		for j := range 3 {
+			group.Blocks = append(group.Blocks, domains.PuzzleCreatePayloadBlock{Value: fmt.Sprintf("Block %d-%d", i, j)})
+		}
+
+		payload.Groups = append(payload.Groups, group)
+	}
+
+	puzzle := payload.ToPuzzle()
+	puzzle.CreatedBy = user
+	puzzle.UserID = user.ID
+
+	created, err := service.New(ctx, puzzle)
+	if err != nil {
+		t.Fatalf("failed to create puzzle: %v", err)
+	}
+
+	return *created
+}
+
+func blockIDs(group domains.PuzzleGroup) []string {
+	ids := make([]string, 0, len(group.Blocks))
+	for _, block := range group.Blocks {
+		ids = append(ids, block.ID)
+	}
+
+	return ids
+}
+
+func TestGameFindByPuzzleID(t *testing.T) {
+	db := memory.New()
+	game := NewGame(GameDependencies{Repository: memory.NewGame(db)})
+	puzzle := NewPuzzle(PuzzleDependencies{Game: memory.NewGame(db), Repository: memory.NewPuzzle(db)})
+
+	ctx, user := authenticate(t, db)
+	created := createPuzzle(t, ctx, puzzle, user)
+
+	started, err := game.Start(ctx, created, user)
+	if err != nil {
+		t.Fatalf("failed to start game: %v", err)
+	}
+	saved, err := game.Save(ctx, *started)
+	if err != nil {
+		t.Fatalf("failed to save game: %v", err)
+	}
+
+	other, _ := authenticate(t, db)
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		found bool
+	}{
+		{name: "no session", ctx: context.Background(), found: false},
+		{name: "anonymous session", ctx: domains.SessionNewContext(context.Background(), domains.NewSession()), found: false},
+		{name: "user without a game", ctx: other, found: false},
+		{name: "user with a game", ctx: ctx, found: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := game.FindByPuzzleID(tt.ctx, ulid.MustParse(created.ID))
+			if !tt.found {
+				var internalErr *internal.Error
+				if !errors.As(err, &internalErr) || internalErr.Code != internal.ErrorCodeNotFound || !errors.Is(err, sql.ErrNoRows) {
+					t.Fatalf("expected a not found error, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected a game, got %v", err)
+			}
+			if found.ID != saved.ID {
+				t.Fatalf("expected game %s, got %s", saved.ID, found.ID)
+			}
+		})
+	}
+}
+
+// Editing a puzzle doesn't change the content of games that were started on an earlier version
+func TestGameFindByPuzzleIDPinsVersion(t *testing.T) {
+	db := memory.New()
+	game := NewGame(GameDependencies{Repository: memory.NewGame(db)})
+	puzzle := NewPuzzle(PuzzleDependencies{Game: memory.NewGame(db), Repository: memory.NewPuzzle(db)})
+
+	ctx, user := authenticate(t, db)
+	created := createPuzzle(t, ctx, puzzle, user)
+
+	started, err := game.Start(ctx, created, user)
+	if err != nil {
+		t.Fatalf("failed to start game: %v", err)
+	}
+	if _, err := game.Save(ctx, *started); err != nil {
+		t.Fatalf("failed to save game: %v", err)
+	}
+
+	update := created
+	update.Difficulty = "HARD"
+	update.Groups = slices.Clone(created.Groups)
+	update.Groups[0].Description = "Edited"
+	if _, err := puzzle.Update(ctx, created, update); err != nil {
+		t.Fatalf("failed to update puzzle: %v", err)
+	}
+
+	found, err := game.FindByPuzzleID(ctx, ulid.MustParse(created.ID))
+	if err != nil {
+		t.Fatalf("failed to find game: %v", err)
+	}
+	if found.PuzzleVersion != 1 {
+		t.Fatalf("expected game to be pinned to version 1, got %d", found.PuzzleVersion)
+	}
+	if found.Puzzle.Difficulty != created.Difficulty || found.Puzzle.Groups[0].Description != created.Groups[0].Description {
+		t.Fatalf("expected the puzzle as it was on version 1, got %s and %q", found.Puzzle.Difficulty, found.Puzzle.Groups[0].Description)
+	}
+
+	current, err := puzzle.Find(ctx, ulid.MustParse(created.ID))
+	if err != nil {
+		t.Fatalf("failed to find puzzle: %v", err)
+	}
+	if current.Difficulty != "HARD" || current.Groups[0].Description != "Edited" {
+		t.Fatalf("expected the puzzle to be edited, got %s and %q", current.Difficulty, current.Groups[0].Description)
+	}
+}
+
+func TestGameGuess(t *testing.T) {
+	db := memory.New()
+	game := NewGame(GameDependencies{Repository: memory.NewGame(db)})
+	puzzle := NewPuzzle(PuzzleDependencies{Game: memory.NewGame(db), Repository: memory.NewPuzzle(db)})
+
+	ctx, user := authenticate(t, db)
+	created := createPuzzle(t, ctx, puzzle, user)
+
+	first := blockIDs(created.Groups[0])
+	wrong := []string{created.Groups[1].Blocks[0].ID, created.Groups[1].Blocks[1].ID, created.Groups[2].Blocks[0].ID}
+
+	tests := []struct {
+		name   string
+		blocks []string
+
+		code      internal.ErrorCode
+		attempts  int
+		correct   int
+		completed bool
+	}{
+		{name: "wrong guess", blocks: wrong, attempts: 1, correct: 0},
+		{name: "correct guess", blocks: first, attempts: 2, correct: 1},
+		{name: "already solved group", blocks: first, code: internal.ErrorCodeBadRequest},
+		{name: "too few blocks", blocks: first[:2], code: internal.ErrorCodeBadRequest},
+		// The last group is solved automatically once only one is left
+		{name: "second to last group", blocks: blockIDs(created.Groups[1]), attempts: 4, correct: 3, completed: true},
+		{name: "completed game", blocks: wrong, code: internal.ErrorCodeBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every guess continues from the game that was last saved
+			started, err := game.Start(ctx, created, user)
+			if err != nil {
+				t.Fatalf("failed to start game: %v", err)
+			}
+
+			saved, err := game.Guess(ctx, *started, domains.GameGuessPayload{Blocks: tt.blocks})
+			if tt.code != "" {
+				var internalErr *internal.Error
+				if !errors.As(err, &internalErr) || internalErr.Code != tt.code {
+					t.Fatalf("expected a %s error, got %v", tt.code, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to guess: %v", err)
+			}
+
+			found, err := game.FindByPuzzleID(ctx, ulid.MustParse(created.ID))
+			if err != nil {
+				t.Fatalf("failed to find game: %v", err)
+			}
+			if found.ID != saved.ID {
+				t.Fatalf("expected game %s to be saved, got %s", saved.ID, found.ID)
+			}
+			if len(found.Attempts) != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, len(found.Attempts))
+			}
+			if len(found.Correct) != tt.correct || int(found.Score) != tt.correct {
+				t.Errorf("expected %d correct groups and score, got %d and %d", tt.correct, len(found.Correct), found.Score)
+			}
+			if found.CompletedAt.IsZero() == tt.completed {
+				t.Errorf("expected completed to be %t", tt.completed)
+			}
+		})
+	}
+}
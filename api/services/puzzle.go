@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/RagOfJoes/puzzlely/domains"
 	"github.com/RagOfJoes/puzzlely/internal"
@@ -10,6 +11,7 @@ import (
 	"github.com/RagOfJoes/puzzlely/repositories"
 	"github.com/oklog/ulid/v2"
 	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
@@ -17,21 +19,32 @@ import (
 
 // Errors
 var (
-	ErrPuzzleCreated    = errors.New("Failed to get created puzzles.")
-	ErrPuzzleLiked      = errors.New("Failed to get liked puzzles.")
-	ErrPuzzleNew        = errors.New("Failed to create new puzzle.")
-	ErrPuzzleNotFound   = errors.New("Puzzle not found.")
-	ErrPuzzleRecent     = errors.New("Failed to get recent puzzles.")
-	ErrPuzzleToggleLike = errors.New("Failed to toggle like on puzzle.")
+	ErrPuzzleCreated     = errors.New("Failed to get created puzzles.")
+	ErrPuzzleDelete      = errors.New("Failed to delete puzzle.")
+	ErrPuzzleImport      = errors.New("You must be logged in to import puzzles.")
+	ErrPuzzleLeaderboard = errors.New("Failed to get puzzle leaderboard.")
+	ErrPuzzleLiked       = errors.New("Failed to get liked puzzles.")
+	ErrPuzzleNew         = errors.New("Failed to create new puzzle.")
+	ErrPuzzleNotFound    = errors.New("Puzzle not found.")
+	ErrPuzzleNotOwner    = errors.New("You must be the creator of this puzzle to modify it.")
+	ErrPuzzleRecent      = errors.New("Failed to get recent puzzles.")
+	ErrPuzzleSearch      = errors.New("Failed to search puzzles.")
+	ErrPuzzleTagged      = errors.New("Failed to get tagged puzzles.")
+	ErrPuzzleUnplayed    = errors.New("You must be logged in to filter out puzzles you've played.")
+	ErrPuzzleToggleLike  = errors.New("Failed to toggle like on puzzle.")
+	ErrPuzzleUpdate      = errors.New("Failed to update puzzle.")
+	ErrPuzzleVersions    = errors.New("Failed to get puzzle versions.")
 )
 
 type Puzzle struct {
 	tracer trace.Tracer
 
+	game       repositories.Game
 	repository repositories.Puzzle
 }
 
 type PuzzleDependencies struct {
+	Game       repositories.Game
 	Repository repositories.Puzzle
 }
 
@@ -41,6 +54,7 @@ func NewPuzzle(d PuzzleDependencies) Puzzle {
 	return Puzzle{
 		tracer: telemetry.Tracer("services.puzzle"),
 
+		game:       d.Game,
 		repository: d.Repository,
 	}
 }
@@ -55,6 +69,12 @@ func (p *Puzzle) New(ctx context.Context, payload domains.Puzzle) (*domains.Puzz
 
 		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
 	}
+	if err := payload.ValidateContent(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
 
 	created, err := p.repository.Create(ctx, payload)
 	if err != nil {
@@ -73,6 +93,37 @@ func (p *Puzzle) New(ctx context.Context, payload domains.Puzzle) (*domains.Puzz
 	return created, nil
 }
 
+// Delete soft deletes a puzzle that is owned by the currently authenticated user
+func (p *Puzzle) Delete(ctx context.Context, id ulid.ULID) error {
+	ctx, span := p.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	puzzle, err := p.Find(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return err
+	}
+
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() || session.User.ID != puzzle.UserID {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleNotOwner)
+
+		return internal.NewErrorf(internal.ErrorCodeForbidden, "%v", ErrPuzzleNotOwner)
+	}
+
+	if err := p.repository.Delete(ctx, puzzle.ID); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleDelete)
+	}
+
+	return nil
+}
+
 func (p *Puzzle) Find(ctx context.Context, id ulid.ULID) (*domains.Puzzle, error) {
 	ctx, span := p.tracer.Start(ctx, "Find", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
@@ -90,10 +141,110 @@ func (p *Puzzle) Find(ctx context.Context, id ulid.ULID) (*domains.Puzzle, error
 
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrPuzzleNotFound)
 	}
+	if !puzzle.IsVisibleTo(sessionUser(ctx)) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleNotFound)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeNotFound, "%v", ErrPuzzleNotFound)
+	}
 
 	return puzzle, nil
 }
 
+// FindForEdit retrieves the full puzzle, with every group and block, for its creator
+func (p *Puzzle) FindForEdit(ctx context.Context, id ulid.ULID) (*domains.Puzzle, error) {
+	ctx, span := p.tracer.Start(ctx, "FindForEdit", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	puzzle, err := p.Find(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() || session.User.ID != puzzle.UserID {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleNotOwner)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeForbidden, "%v", ErrPuzzleNotOwner)
+	}
+
+	return puzzle, nil
+}
+
+// FindForPlayer retrieves the player-facing representation of a puzzle. Only the groups that the currently
+// authenticated user has solved are revealed
+func (p *Puzzle) FindForPlayer(ctx context.Context, id ulid.ULID) (*domains.PlayerPuzzle, error) {
+	ctx, span := p.tracer.Start(ctx, "FindForPlayer", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	puzzle, err := p.Find(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	correct, err := p.game.GetCorrect(ctx, []string{puzzle.ID})
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleNotFound)
+	}
+
+	playerPuzzle := domains.NewPlayerPuzzle(*puzzle, correct[puzzle.ID])
+	if err := playerPuzzle.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleNotFound)
+	}
+
+	return &playerPuzzle, nil
+}
+
+// FindVersions retrieves every version of a puzzle, from oldest to newest, for its creator. Each version, other than
+// the first, lists what changed from the version before it
+func (p *Puzzle) FindVersions(ctx context.Context, id ulid.ULID) ([]domains.PuzzleVersion, error) {
+	ctx, span := p.tracer.Start(ctx, "FindVersions", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if _, err := p.FindForEdit(ctx, id); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	versions, err := p.repository.GetVersions(ctx, id.String())
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleVersions)
+	}
+
+	for i := range versions {
+		if err := versions[i].Validate(); err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleVersions)
+		}
+
+		if i > 0 {
+			versions[i].Changes = domains.DiffPuzzleVersions(versions[i-1], versions[i])
+		}
+	}
+
+	return versions, nil
+}
+
 func (p *Puzzle) FindCreated(ctx context.Context, id string, opts domains.PuzzleCursorPaginationOpts) (*domains.PuzzleSummaryConnection, error) {
 	ctx, span := p.tracer.Start(ctx, "FindCreated", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
@@ -134,6 +285,86 @@ func (p *Puzzle) FindCreated(ctx context.Context, id string, opts domains.Puzzle
 	return connection, nil
 }
 
+// FindLeaderboard retrieves the ranked, completed games for a puzzle along with the currently authenticated user's own
+// entry, if they have one
+func (p *Puzzle) FindLeaderboard(ctx context.Context, id ulid.ULID, opts domains.LeaderboardOpts) (*domains.LeaderboardConnection, error) {
+	ctx, span := p.tracer.Start(ctx, "FindLeaderboard", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := opts.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrPuzzleLeaderboard)
+	}
+
+	puzzle, err := p.Find(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	eg := errgroup.Group{}
+
+	var entries []domains.LeaderboardEntry
+	eg.Go(func() error {
+		found, err := p.game.GetLeaderboard(ctx, puzzle.ID, opts)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleLeaderboard)
+		}
+
+		entries = found
+
+		return nil
+	})
+
+	var me *domains.LeaderboardEntry
+	eg.Go(func() error {
+		session := domains.SessionFromContext(ctx)
+		if session == nil || !session.IsAuthenticated() {
+			return nil
+		}
+
+		found, err := p.game.GetLeaderboardEntry(ctx, puzzle.ID, session.User.ID)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleLeaderboard)
+		}
+
+		me = found
+
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	// Validate results
+	for _, entry := range entries {
+		if err := entry.Validate(); err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleLeaderboard)
+		}
+	}
+
+	connection, err := domains.BuildLeaderboardConnection(entries, opts.Limit, me)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleLeaderboard)
+	}
+
+	return connection, nil
+}
+
 func (p *Puzzle) FindLiked(ctx context.Context, id string, opts domains.PuzzleCursorPaginationOpts) (*domains.PuzzleSummaryConnection, error) {
 	ctx, span := p.tracer.Start(ctx, "FindLiked", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
@@ -174,7 +405,7 @@ func (p *Puzzle) FindLiked(ctx context.Context, id string, opts domains.PuzzleCu
 	return connection, nil
 }
 
-func (p *Puzzle) FindRecent(ctx context.Context, opts domains.PuzzleCursorPaginationOpts) (*domains.PuzzleConnection, error) {
+func (p *Puzzle) FindRecent(ctx context.Context, opts domains.PuzzleCursorPaginationOpts) (*domains.PlayerPuzzleConnection, error) {
 	ctx, span := p.tracer.Start(ctx, "FindRecent", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
 
@@ -211,13 +442,21 @@ func (p *Puzzle) FindRecent(ctx context.Context, opts domains.PuzzleCursorPagina
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleRecent)
 	}
 	if len(connection.Edges) == 0 {
-		return connection, nil
+		playerConnection, err := domains.BuildPlayerPuzzleConnection(*connection, nil)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleRecent)
+		}
+
+		return playerConnection, nil
 	}
 
 	eg := errgroup.Group{}
 
 	eg.Go(func() error {
-		next, err := p.repository.GetNextForRecent(ctx, puzzles[len(puzzles)-1].CreatedAt.Format("2006-01-02 15:04:05.000000"))
+		next, err := p.repository.GetNextForRecent(ctx, puzzles[len(puzzles)-1].CreatedAt.Format("2006-01-02 15:04:05.000000"), opts.Language)
 		if err != nil {
 			return internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleRecent)
 		}
@@ -234,7 +473,7 @@ func (p *Puzzle) FindRecent(ctx context.Context, opts domains.PuzzleCursorPagina
 			return nil
 		}
 
-		previous, err := p.repository.GetPreviousForRecent(ctx, puzzles[0].CreatedAt.Format("2006-01-02 15:04:05.000000"))
+		previous, err := p.repository.GetPreviousForRecent(ctx, puzzles[0].CreatedAt.Format("2006-01-02 15:04:05.000000"), opts.Language)
 		if err != nil {
 			return internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleRecent)
 		}
@@ -246,6 +485,23 @@ func (p *Puzzle) FindRecent(ctx context.Context, opts domains.PuzzleCursorPagina
 		return nil
 	})
 
+	var correct map[string][]string
+	eg.Go(func() error {
+		ids := make([]string, 0)
+		for _, puzzle := range puzzles {
+			ids = append(ids, puzzle.ID)
+		}
+
+		found, err := p.game.GetCorrect(ctx, ids)
+		if err != nil {
+			return internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleRecent)
+		}
+
+		correct = found
+
+		return nil
+	})
+
 	if err := eg.Wait(); err != nil {
 		span.SetStatus(codes.Error, "")
 		span.RecordError(err)
@@ -253,6 +509,159 @@ func (p *Puzzle) FindRecent(ctx context.Context, opts domains.PuzzleCursorPagina
 		return nil, err
 	}
 
+	playerConnection, err := domains.BuildPlayerPuzzleConnection(*connection, correct)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleRecent)
+	}
+
+	return playerConnection, nil
+}
+
+// FindTagged retrieves the puzzles that have the tag with the given slug, newest first
+func (p *Puzzle) FindTagged(ctx context.Context, slug string, opts domains.PuzzleCursorPaginationOpts) (*domains.PuzzleSummaryConnection, error) {
+	ctx, span := p.tracer.Start(ctx, "FindTagged", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := opts.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrPuzzleTagged)
+	}
+
+	puzzles, err := p.repository.GetTagged(ctx, slug, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleTagged)
+	}
+
+	// Validate results
+	for _, puzzle := range puzzles {
+		if err := puzzle.Validate(); err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleTagged)
+		}
+	}
+
+	connection, err := domains.BuildPuzzleSummaryConnection(puzzles, opts.Limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleTagged)
+	}
+
+	return connection, nil
+}
+
+// Import creates every valid puzzle in the given file for the currently authenticated user. Puzzles are created one by
+// one, so a puzzle that fails doesn't prevent the rest from being created
+func (p *Puzzle) Import(ctx context.Context, file domains.PuzzleFile) (*domains.PuzzleImport, error) {
+	ctx, span := p.tracer.Start(ctx, "Import", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := file.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleImport)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeUnauthorized, "%v", ErrPuzzleImport)
+	}
+
+	result := domains.PuzzleImport{
+		Results: make([]domains.PuzzleImportResult, 0, len(file.Puzzles)),
+	}
+	for i, payload := range file.Puzzles {
+		item := domains.PuzzleImportResult{
+			Index: i,
+		}
+
+		if err := payload.Validate(); err != nil {
+			item.Error = err.Error()
+		} else {
+			newPuzzle := payload.ToPuzzle()
+			newPuzzle.CreatedBy = *session.User
+			newPuzzle.UserID = session.User.ID
+
+			created, err := p.New(ctx, newPuzzle)
+			if err != nil {
+				item.Error = err.Error()
+			} else {
+				item.Puzzle = created
+			}
+		}
+
+		if item.Error != "" {
+			result.Failed++
+		} else {
+			result.Created++
+		}
+
+		result.Results = append(result.Results, item)
+	}
+
+	return &result, nil
+}
+
+func (p *Puzzle) Search(ctx context.Context, opts domains.PuzzleSearchOpts) (*domains.PuzzleSummaryConnection, error) {
+	ctx, span := p.tracer.Start(ctx, "Search", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := opts.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrPuzzleSearch)
+	}
+
+	session := domains.SessionFromContext(ctx)
+	if opts.Unplayed && (session == nil || !session.IsAuthenticated()) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleUnplayed)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeUnauthorized, "%v", ErrPuzzleUnplayed)
+	}
+
+	puzzles, err := p.repository.Search(ctx, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleSearch)
+	}
+
+	// Validate results
+	for _, puzzle := range puzzles {
+		if err := puzzle.Validate(); err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleSearch)
+		}
+	}
+
+	connection, err := domains.BuildPuzzleSummaryConnectionForSearch(puzzles, opts.Limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleSearch)
+	}
+
 	return connection, nil
 }
 
@@ -279,6 +688,64 @@ func (p *Puzzle) ToggleLike(ctx context.Context, id ulid.ULID) (*domains.PuzzleL
 	return like, nil
 }
 
-func (p *Puzzle) Update(ctx context.Context, old, update domains.Puzzle) (*domains.PuzzleLike, error) {
-	return nil, nil
+// Update updates a puzzle that is owned by the currently authenticated user
+func (p *Puzzle) Update(ctx context.Context, old, update domains.Puzzle) (*domains.Puzzle, error) {
+	ctx, span := p.tracer.Start(ctx, "Update", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() || session.User.ID != old.UserID {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrPuzzleNotOwner)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeForbidden, "%v", ErrPuzzleNotOwner)
+	}
+
+	// Make sure only certain fields are updated
+	update.ID = old.ID
+	update.MaxAttempts = old.MaxAttempts
+	update.Version = old.Version + 1
+	update.GroupCount = old.GroupCount
+	update.GroupSize = old.GroupSize
+	update.Language = old.Language
+	update.CreatedAt = old.CreatedAt
+	update.UpdatedAt = bun.NullTime{
+		Time: time.Now(),
+	}
+	update.UserID = old.UserID
+	update.CreatedBy = old.CreatedBy
+
+	if err := update.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
+	if err := update.ValidateContent(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	updated, err := p.repository.Update(ctx, update)
+	if err != nil && errors.Is(err, repositories.ErrPuzzleOutdated) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", repositories.ErrPuzzleOutdated)
+	} else if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleUpdate)
+	}
+	if err := updated.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrPuzzleUpdate)
+	}
+
+	return updated, nil
 }
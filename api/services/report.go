@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/oklog/ulid/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrReportCreate        = errors.New("Failed to file report.")
+	ErrReportForbidden     = errors.New("You aren't allowed to report puzzles or users.")
+	ErrReportInvalidAction = errors.New("Only reported puzzles can be hidden.")
+	ErrReportNotFound      = errors.New("Report not found.")
+	ErrReportNotModerator  = errors.New("You must be a moderator to review reports.")
+	ErrReportQueue         = errors.New("Failed to get reports.")
+	ErrReportResolve       = errors.New("Failed to resolve report.")
+	ErrReportSelf          = errors.New("You can't report yourself.")
+)
+
+type Report struct {
+	tracer trace.Tracer
+
+	puzzle     repositories.Puzzle
+	repository repositories.Report
+	user       repositories.User
+}
+
+type ReportDependencies struct {
+	Puzzle     repositories.Puzzle
+	Repository repositories.Report
+	User       repositories.User
+}
+
+func NewReport(d ReportDependencies) Report {
+	logrus.Print("Created Report Service")
+
+	return Report{
+		tracer: telemetry.Tracer("services.report"),
+
+		puzzle:     d.Puzzle,
+		repository: d.Repository,
+		user:       d.User,
+	}
+}
+
+// NewForPuzzle reports a puzzle. The puzzle's creator is recorded as the reported user so that they can be suspended
+func (r *Report) NewForPuzzle(ctx context.Context, id ulid.ULID, payload domains.ReportPayload) (*domains.Report, error) {
+	ctx, span := r.tracer.Start(ctx, "NewForPuzzle", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	puzzle, err := r.puzzle.Get(ctx, id.String())
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrPuzzleNotFound)
+	}
+
+	return r.create(ctx, payload, puzzle.ID, puzzle.UserID)
+}
+
+// NewForUser reports a user
+func (r *Report) NewForUser(ctx context.Context, id ulid.ULID, payload domains.ReportPayload) (*domains.Report, error) {
+	ctx, span := r.tracer.Start(ctx, "NewForUser", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	user, err := r.user.Get(ctx, id.String())
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrUserDoesNotExist)
+	}
+
+	return r.create(ctx, payload, "", user.ID)
+}
+
+// FindQueue retrieves the reports that are waiting on a moderator, oldest first
+func (r *Report) FindQueue(ctx context.Context, opts domains.ReportCursorPaginationOpts) (*domains.ReportConnection, error) {
+	ctx, span := r.tracer.Start(ctx, "FindQueue", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := authorize(ctx, domains.PermissionReviewReports, ErrReportNotModerator); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if err := opts.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrReportQueue)
+	}
+
+	reports, err := r.repository.GetOpen(ctx, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrReportQueue)
+	}
+
+	// Validate results
+	for _, report := range reports {
+		if err := report.Validate(); err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrReportQueue)
+		}
+	}
+
+	connection, err := domains.BuildReportConnection(reports, opts.Limit)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrReportQueue)
+	}
+
+	return connection, nil
+}
+
+// Resolve closes a report and applies the moderator's action to the reported puzzle, or user
+func (r *Report) Resolve(ctx context.Context, id ulid.ULID, payload domains.ReportResolvePayload) (*domains.Report, error) {
+	ctx, span := r.tracer.Start(ctx, "Resolve", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := authorize(ctx, domains.PermissionReviewReports, ErrReportNotModerator); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	report, err := r.repository.Get(ctx, id.String())
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrReportNotFound)
+	}
+	if payload.Action == "HIDE_PUZZLE" && report.PuzzleID == "" {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrReportInvalidAction)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrReportInvalidAction)
+	}
+
+	session := domains.SessionFromContext(ctx)
+
+	report.Action = payload.Action
+	report.ResolvedAt = bun.NullTime{
+		Time: time.Now(),
+	}
+	report.ResolvedBy = session.User.ID
+	if err := report.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrReportResolve)
+	}
+
+	resolved, err := r.repository.Resolve(ctx, *report)
+	if errors.Is(err, repositories.ErrReportResolved) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err)
+	} else if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrReportResolve)
+	}
+	if err := resolved.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrReportResolve)
+	}
+
+	return resolved, nil
+}
+
+// Files a report, on behalf of the session's user, against the given puzzle or user
+func (r *Report) create(ctx context.Context, payload domains.ReportPayload, puzzleID string, reportedUserID string) (*domains.Report, error) {
+	span := trace.SpanFromContext(ctx)
+
+	if err := authorize(ctx, domains.PermissionReport, ErrReportForbidden); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	session := domains.SessionFromContext(ctx)
+	if session.User.ID == reportedUserID {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrReportSelf)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrReportSelf)
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	newReport := domains.Report{
+		ID:      ulid.Make().String(),
+		Reason:  payload.Reason,
+		Details: payload.Details,
+
+		CreatedAt: time.Now(),
+
+		PuzzleID:       puzzleID,
+		ReportedUserID: reportedUserID,
+		UserID:         session.User.ID,
+	}
+	if err := newReport.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrReportCreate)
+	}
+
+	created, err := r.repository.Create(ctx, newReport)
+	if errors.Is(err, repositories.ErrReportDuplicate) {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", err)
+	} else if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrReportCreate)
+	}
+	if err := created.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrReportCreate)
+	}
+
+	return created, nil
+}
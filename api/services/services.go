@@ -1,8 +1,38 @@
 package services
 
-import "errors"
+import (
+	"context"
+	"errors"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+)
 
 // Common errors
 var (
 	ErrUnauthorized = errors.New("You must be logged in to access this resource.")
 )
+
+// Returns the user of the session, or nil when the session isn't authenticated
+func sessionUser(ctx context.Context) *domains.User {
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() {
+		return nil
+	}
+
+	return session.User
+}
+
+// Checks whether the session's user has been granted the permission. `forbidden` is returned, as a forbidden error, when
+// they haven't
+func authorize(ctx context.Context, permission domains.Permission, forbidden error) error {
+	session := domains.SessionFromContext(ctx)
+	if session == nil || !session.IsAuthenticated() {
+		return internal.NewErrorf(internal.ErrorCodeUnauthorized, "%v", ErrUnauthorized)
+	}
+	if !session.User.Can(permission) {
+		return internal.NewErrorf(internal.ErrorCodeForbidden, "%v", forbidden)
+	}
+
+	return nil
+}
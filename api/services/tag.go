@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RagOfJoes/puzzlely/domains"
+	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/telemetry"
+	"github.com/RagOfJoes/puzzlely/repositories"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Errors
+var (
+	ErrTagNotFound = errors.New("Tag not found.")
+	ErrTagSearch   = errors.New("Failed to search tags.")
+)
+
+type Tag struct {
+	tracer trace.Tracer
+
+	repository repositories.Tag
+}
+
+type TagDependencies struct {
+	Repository repositories.Tag
+}
+
+func NewTag(d TagDependencies) Tag {
+	logrus.Print("Created Tag Service")
+
+	return Tag{
+		tracer: telemetry.Tracer("services.tag"),
+
+		repository: d.Repository,
+	}
+}
+
+// Find retrieves the tag with the given slug
+func (t *Tag) Find(ctx context.Context, slug string) (*domains.Tag, error) {
+	ctx, span := t.tracer.Start(ctx, "Find", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	tag, err := t.repository.Get(ctx, slug)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrTagNotFound)
+	}
+	if err := tag.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrTagNotFound)
+	}
+
+	return tag, nil
+}
+
+// Search retrieves the most used tags that start with the query, for autocomplete
+func (t *Tag) Search(ctx context.Context, opts domains.TagSearchOpts) ([]domains.Tag, error) {
+	ctx, span := t.tracer.Start(ctx, "Search", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := opts.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeBadRequest, "%v", ErrTagSearch)
+	}
+
+	tags, err := t.repository.Search(ctx, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrTagSearch)
+	}
+
+	// Validate results
+	for _, tag := range tags {
+		if err := tag.Validate(); err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrTagSearch)
+		}
+	}
+
+	return tags, nil
+}
@@ -3,10 +3,12 @@ package services
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/RagOfJoes/puzzlely/domains"
 	"github.com/RagOfJoes/puzzlely/internal"
+	"github.com/RagOfJoes/puzzlely/internal/config"
 	"github.com/RagOfJoes/puzzlely/repositories"
 	"github.com/sirupsen/logrus"
 	"github.com/uptrace/bun"
@@ -22,6 +24,10 @@ var (
 	ErrUserDoesNotExist    = errors.New("User does not exist.")
 	ErrUserInvalid         = errors.New("Invalid user.")
 	ErrUserInvalidUsername = errors.New("Username is not available.")
+	ErrUserNotAdmin        = errors.New("You aren't allowed to manage roles.")
+	ErrUserRoleSelf        = errors.New("You can't change your own role.")
+	ErrUserStats           = errors.New("Failed to get user stats.")
+	ErrUserStreak          = errors.New("Failed to get user streak.")
 	ErrUserUpdate          = errors.New("Failed to update user.")
 )
 
@@ -29,10 +35,14 @@ var (
 type User struct {
 	tracer trace.Tracer
 
+	admins []string
+
 	repository repositories.User
 }
 
 type UserDependencies struct {
+	Config config.Configuration
+
 	Repository repositories.User
 }
 
@@ -43,6 +53,8 @@ func NewUser(dependencies UserDependencies) User {
 	return User{
 		tracer: otel.Tracer("services.user"),
 
+		admins: dependencies.Config.Roles.Admins,
+
 		repository: dependencies.Repository,
 	}
 }
@@ -98,6 +110,60 @@ func (u *User) Find(ctx context.Context, id string, strict bool) (*domains.User,
 	return user, nil
 }
 
+// FindStats retrieves the aggregated games and puzzles of a user
+func (u *User) FindStats(ctx context.Context, id string) (*domains.UserStats, error) {
+	ctx, span := u.tracer.Start(ctx, "FindStats", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if _, err := u.Find(ctx, id, false); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	stats, err := u.repository.GetStats(ctx, id)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrUserStats)
+	}
+	if err := stats.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrUserStats)
+	}
+
+	return stats, nil
+}
+
+// FindStreak retrieves the play, and win, streaks of a user. Streaks that were broken since the user last played are
+// reported as 0
+func (u *User) FindStreak(ctx context.Context, user domains.User) (*domains.UserStreak, error) {
+	ctx, span := u.tracer.Start(ctx, "FindStreak", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	streak, err := u.repository.GetStreak(ctx, user.ID)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrUserStreak)
+	}
+
+	streak.Expire(domains.StreakDay(time.Now(), user.Location()))
+	if err := streak.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrUserStreak)
+	}
+
+	return streak, nil
+}
+
 // FindWithConnection retrieves a user with one of their connection
 func (u *User) FindWithConnection(ctx context.Context, payload domains.Connection) (*domains.User, error) {
 	ctx, span := u.tracer.Start(ctx, "FindWithConnection", trace.WithSpanKind(trace.SpanKindInternal))
@@ -117,6 +183,22 @@ func (u *User) FindWithConnection(ctx context.Context, payload domains.Connectio
 		return nil, internal.WrapErrorf(err, internal.ErrorCodeNotFound, "%v", ErrUserDoesNotExist)
 	}
 
+	// Promote the users listed in the config, that haven't been promoted yet
+	if slices.Contains(u.admins, user.ID) && user.Role != "ADMIN" {
+		user.Role = "ADMIN"
+		user.UpdatedAt = bun.NullTime{
+			Time: time.Now(),
+		}
+
+		user, err = u.repository.Update(ctx, *user)
+		if err != nil {
+			span.SetStatus(codes.Error, "")
+			span.RecordError(err)
+
+			return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrUserUpdate)
+		}
+	}
+
 	return user, nil
 }
 
@@ -136,6 +218,7 @@ func (u *User) Update(ctx context.Context, payload domains.User) (*domains.User,
 
 	// Make sure only certain fields are updated
 	payload.ID = session.User.ID
+	payload.Role = session.User.Role
 	payload.State = session.User.State
 	payload.CreatedAt = session.User.CreatedAt
 	payload.UpdatedAt = bun.NullTime{
@@ -169,7 +252,67 @@ func (u *User) Update(ctx context.Context, payload domains.User) (*domains.User,
 	return user, nil
 }
 
-// Delete deletes a user
+// UpdateRole changes the role of another user
+func (u *User) UpdateRole(ctx context.Context, id string, payload domains.UserRolePayload) (*domains.User, error) {
+	ctx, span := u.tracer.Start(ctx, "UpdateRole", trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	if err := authorize(ctx, domains.PermissionManageRoles, ErrUserNotAdmin); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if err := payload.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", err)
+	}
+
+	// Prevents admins from locking themselves out
+	session := domains.SessionFromContext(ctx)
+	if session.User.ID == id {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(ErrUserRoleSelf)
+
+		return nil, internal.NewErrorf(internal.ErrorCodeBadRequest, "%v", ErrUserRoleSelf)
+	}
+
+	user, err := u.Find(ctx, id, false)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, err
+	}
+	if user.Role == payload.Role {
+		return user, nil
+	}
+
+	user.Role = payload.Role
+	user.UpdatedAt = bun.NullTime{
+		Time: time.Now(),
+	}
+
+	updated, err := u.repository.Update(ctx, *user)
+	if err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrUserUpdate)
+	}
+	if err := updated.Validate(); err != nil {
+		span.SetStatus(codes.Error, "")
+		span.RecordError(err)
+
+		return nil, internal.WrapErrorf(err, internal.ErrorCodeInternal, "%v", ErrUserUpdate)
+	}
+
+	return updated, nil
+}
+
+// Delete deletes a user's account. The user's puzzles and games are kept but their username is anonymized
 func (u *User) Delete(ctx context.Context, id string) error {
 	ctx, span := u.tracer.Start(ctx, "Delete", trace.WithSpanKind(trace.SpanKindInternal))
 	defer span.End()
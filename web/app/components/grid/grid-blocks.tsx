@@ -5,10 +5,33 @@ import { Primitive } from "@radix-ui/react-primitive";
 
 import { cn } from "@/lib/cn";
 
-export type GridBlocksProps = ComponentPropsWithoutRef<typeof Primitive.div>;
+// Class names are written out in full so that Tailwind picks them up
+const COLUMNS: Record<number, string> = {
+	3: "grid-cols-3",
+	4: "grid-cols-4",
+	5: "grid-cols-5",
+	6: "grid-cols-6",
+};
+const ROWS: Record<number, string> = {
+	3: "grid-rows-3",
+	4: "grid-rows-4",
+	5: "grid-rows-5",
+	6: "grid-rows-6",
+};
+
+export type GridBlocksProps = ComponentPropsWithoutRef<typeof Primitive.div> & {
+	/**
+	 * Number of blocks in each row, which is the puzzle's `group_size`
+	 */
+	columns?: number;
+	/**
+	 * Number of rows, which is the puzzle's `group_count`
+	 */
+	rows?: number;
+};
 
 export const GridBlocks = forwardRef<ElementRef<typeof Primitive.div>, GridBlocksProps>(
-	({ className, ...props }, ref) => {
+	({ className, columns = 4, rows = 4, ...props }, ref) => {
 		const children = useMemo(
 			() =>
 				Children.toArray(props.children).filter(
@@ -22,7 +45,9 @@ export const GridBlocks = forwardRef<ElementRef<typeof Primitive.div>, GridBlock
 			<Primitive.div
 				{...props}
 				className={cn(
-					"relative grid h-full w-full grid-cols-4 grid-rows-4 gap-1",
+					"relative grid h-full w-full gap-1",
+					COLUMNS[columns],
+					ROWS[rows],
 
 					'before:col-start-1 before:col-end-1 before:row-start-1 before:row-end-1 before:w-0 before:content-[""]',
 
@@ -21,7 +21,7 @@ import { arePuzzleBlocksSameGroup } from "@/lib/are-puzzle-blocks-same-group";
 import { cn } from "@/lib/cn";
 import { getPuzzleBlocksFromAttempts } from "@/lib/get-puzzle-blocks-from-attempts";
 import { omit } from "@/lib/omit";
-import type { PuzzleBlock } from "@/types/puzzle";
+import type { PlayerPuzzleBlock } from "@/types/player-puzzle";
 import type { PuzzleLike } from "@/types/puzzle-like";
 import type { Response } from "@/types/response";
 
@@ -39,12 +39,12 @@ export const GridMenu = forwardRef<ElementRef<"div">, GridMenuProps>(
 
 		const [isHidden, toggleIsHidden] = useState(false);
 
-		const attempts = useMemo<{ blocks: PuzzleBlock[]; isCorrect: boolean }[]>(() => {
+		const attempts = useMemo<{ blocks: PlayerPuzzleBlock[]; isCorrect: boolean }[]>(() => {
 			const joined = getPuzzleBlocksFromAttempts(game, puzzle);
 
 			return joined.map((attempt) => ({
 				blocks: attempt,
-				isCorrect: arePuzzleBlocksSameGroup(attempt),
+				isCorrect: arePuzzleBlocksSameGroup(attempt, puzzle.groups),
 			}));
 		}, [game, puzzle]);
 
@@ -2,47 +2,48 @@ import { useCallback, useEffect, useState } from "react";
 
 import { useKey } from "@rwh/react-keystrokes";
 import shuffle from "lodash.shuffle";
+import { useFetcher } from "react-router";
 
 import { useGameLocalContext } from "@/hooks/use-game-local";
-import { arePuzzleBlocksSameGroup } from "@/lib/are-puzzle-blocks-same-group";
 import { createContext } from "@/lib/create-context";
-import { getPuzzleBlocksFromAttempts } from "@/lib/get-puzzle-blocks-from-attempts";
-import { groupBy } from "@/lib/group-by";
+import { orderPuzzleBlocks } from "@/lib/order-puzzle-blocks";
 import { pickLatestGame } from "@/lib/pick-latest-game";
-import { uniqueBy } from "@/lib/unique-by";
+import { transformGameToPayload } from "@/lib/transform-game-to-payload";
+import type { action } from "@/routes/games.guess.$id";
 import type { GamePayload } from "@/types/game-payload";
-import type { Puzzle, PuzzleBlock } from "@/types/puzzle";
+import type { PlayerPuzzle, PlayerPuzzleBlock } from "@/types/player-puzzle";
 
 export type UseGameProps = {
 	game?: GamePayload;
-	puzzle: Puzzle;
+	puzzle: PlayerPuzzle;
 };
 
 export type UseGame = [
 	state: {
 		// Blocks that will be rendered
-		blocks: PuzzleBlock[];
+		blocks: PlayerPuzzleBlock[];
 		// Existing/current game state
 		game: GamePayload;
 		// Flag to determine if the game is over. Will be triggered if the user has reached the maximum number of attempts
 		isGameOver: boolean;
+		// Flag to determine if the user's guess is still being checked by the API
+		isGuessing: boolean;
 		// Flag to determine if the game is still loading
 		isLoading: boolean;
 		// Flag to determine if the user has won the game
 		isWinnerWinnerChickenDinner: boolean;
 		// Flag to determine if the elements inside the `selected` state are wrong
 		isWrong: boolean;
-		// Puzzle this game is for
-		puzzle: Puzzle;
+		// Puzzle this game is for. Only includes the groups that the user has solved
+		puzzle: PlayerPuzzle;
 		// List of elements that stores the user's currently selected blocks
-		selected: PuzzleBlock[];
+		selected: PlayerPuzzleBlock[];
 		// Number of wrong attempts
 		wrongAttempts: number;
 	},
 	actions: {
-		// When the user selects a block
-		// NOTE: A majority of the game logic is handled here
-		onBlockSelect: (block: PuzzleBlock) => void;
+		// When the user selects a block. Once enough blocks are selected, they're sent to the API to be checked
+		onBlockSelect: (block: PlayerPuzzleBlock) => void;
 		// When the user either presses the give up key or clicks the give up button
 		onGiveUp: () => void;
 		// When the user either presses the shuffle key or clicks the shuffle button
@@ -59,6 +60,10 @@ export const [GameProvider, useGameContext] = createContext<UseGame>({
 export function useGame(props: UseGameProps): UseGame {
 	const [local] = useGameLocalContext();
 
+	const fetcher = useFetcher<typeof action>({
+		key: "games.guess",
+	});
+
 	/**
 	 * Game state
 	 */
@@ -87,6 +92,7 @@ export function useGame(props: UseGameProps): UseGame {
 	const isShuffle = useKey("]");
 
 	const [isGameOver, toggleIsGameOver] = useState<UseGame[0]["isGameOver"]>(false);
+	const isGuessing: UseGame[0]["isGuessing"] = fetcher.state !== "idle";
 	const [isLoading, toggleIsLoading] = useState<UseGame[0]["isLoading"]>(true);
 	const [isWinnerWinnerChickenDinner, toggleIsWinnerWinnerChickenDinner] =
 		useState<UseGame[0]["isWinnerWinnerChickenDinner"]>(false);
@@ -97,13 +103,20 @@ export function useGame(props: UseGameProps): UseGame {
 	 */
 
 	const onBlockSelect: UseGame[1]["onBlockSelect"] = useCallback(
-		(block: PuzzleBlock) => {
-			const isAlreadyInCorrect = game.correct.includes(block.puzzle_group_id);
+		(block: PlayerPuzzleBlock) => {
+			const isAlreadyInCorrect = puzzle.groups.some((group) => group.blocks.includes(block.id));
 			const isComplete = !!game.completed_at;
 			const isTooMuchAttempts = puzzle.max_attempts > 0 && wrongAttempts >= puzzle.max_attempts;
-			const isTooMuchSelected = selected.length >= 4;
-
-			if (isAlreadyInCorrect || isComplete || isTooMuchAttempts || isTooMuchSelected || isWrong) {
+			const isTooMuchSelected = selected.length >= puzzle.group_size;
+
+			if (
+				isAlreadyInCorrect ||
+				isComplete ||
+				isGuessing ||
+				isTooMuchAttempts ||
+				isTooMuchSelected ||
+				isWrong
+			) {
 				return;
 			}
 
@@ -120,95 +133,25 @@ export function useGame(props: UseGameProps): UseGame {
 			setSelected(newSelected);
 
 			// If `selected` state isn't full yet then exit
-			if (newSelected.length < 4) {
+			if (newSelected.length < puzzle.group_size) {
 				return;
 			}
 
-			// Append `selected` state's IDs to Game's `attempts` field
-			const newAttempts = [...game.attempts, newSelected.map((select) => select.id)];
-
-			// If any of the Blocks are wrong
-			if (newSelected.some((select) => select.puzzle_group_id !== block.puzzle_group_id)) {
-				// Check if reached `maxAttempts`
-				const isMaxAttempt = wrongAttempts + 1 >= puzzle.max_attempts;
-
-				setGame((prev) => ({
-					...prev,
-					attempts: newAttempts,
-
-					completed_at: isMaxAttempt ? new Date() : null,
-				}));
-				setWrongAttempts((prev) => prev + 1);
-
-				// Toggle `wrong` state to true to trigger animation
-				toggleIsWrong(true);
-
-				// If reached `maxAttempts`, then, toggle `isGameOver` state to true`
-				if (isMaxAttempt) {
-					toggleIsGameOver(true);
-				}
-
-				return;
-			}
-
-			// Clone `correct` state then append `groupID`
-			const newCorrect = [...game.correct, block.puzzle_group_id];
-
-			// If User has linked all Groups correctly
-			const isGuessedAll = newCorrect.length === puzzle.groups.length - 1;
-			if (isGuessedAll) {
-				// Append last `group_id` to cloned `correct` state
-				const lastGroup = puzzle.groups.filter((g) => !newCorrect.includes(g.id)).map((g) => g.id);
-
-				// Make sure there's only one group left also make typescript happy
-				if (lastGroup.length === 1 && typeof lastGroup[0] === "string") {
-					newAttempts.push(
-						blocks.filter((b) => b.puzzle_group_id === lastGroup[0]).map((b) => b.id),
-					);
-					newCorrect.push(lastGroup[0]);
-				}
-			}
-
-			// 1. Filter blocks so only blocks that belong to a correct group is returned
-			// 2. Group by `groupID`
-			// 3. Flatten array
-			const grouped = groupBy(
-				blocks.filter((b) => newCorrect.includes(b.puzzle_group_id)),
-				(b) => b.puzzle_group_id,
-			).flat();
-
-			// 1. Order blocks by putting correctly guessed on top
-			// 2. Remove duplicates
-			const newBlocks = uniqueBy([...grouped, ...blocks], (item) => item.id);
-
-			const newScore = isGuessedAll ? puzzle.groups.length : game.score + 1;
-
-			// Update game state
-			setGame((prev) => ({
-				...prev,
-				attempts: newAttempts,
-				correct: newCorrect,
-				score: newScore,
-
-				completed_at: isGuessedAll ? new Date() : null,
-			}));
-
-			// Update block states
-			setSelected([]);
-			setBlocks(newBlocks);
-
-			if (isGuessedAll) {
-				toggleIsWinnerWinnerChickenDinner(true);
-			}
+			// The groups aren't known until they're solved so the API decides whether the guess is correct
+			fetcher.submit(JSON.stringify({ blocks: newSelected.map((select) => select.id) }), {
+				action: `/games/guess/${puzzle.id}`,
+				encType: "application/json",
+				method: "POST",
+			});
 		},
 		[
-			blocks,
-			game.attempts,
+			fetcher,
 			game.completed_at,
-			game.correct,
-			game.score,
+			isGuessing,
 			isWrong,
+			puzzle.group_size,
 			puzzle.groups,
+			puzzle.id,
 			puzzle.max_attempts,
 			selected,
 			wrongAttempts,
@@ -226,11 +169,11 @@ export function useGame(props: UseGameProps): UseGame {
 	}, []);
 
 	const onShuffle: UseGame[1]["onShuffle"] = useCallback(() => {
-		const correctBlocks: PuzzleBlock[] = [];
-		const incorrectBlocks: PuzzleBlock[] = [];
+		const correctBlocks: PlayerPuzzleBlock[] = [];
+		const incorrectBlocks: PlayerPuzzleBlock[] = [];
 
 		blocks.forEach((block) => {
-			if (game.correct.includes(block.puzzle_group_id)) {
+			if (puzzle.groups.some((group) => group.blocks.includes(block.id))) {
 				correctBlocks.push(block);
 				return;
 			}
@@ -239,7 +182,7 @@ export function useGame(props: UseGameProps): UseGame {
 		});
 
 		setBlocks([...correctBlocks, ...shuffle(incorrectBlocks)]);
-	}, [blocks, game.correct]);
+	}, [blocks, puzzle.groups]);
 
 	/**
 	 * Effects
@@ -261,12 +204,11 @@ export function useGame(props: UseGameProps): UseGame {
 		}
 
 		const newGame: GamePayload = pickLatestGame(props.game, local.games[props.puzzle.id]);
-		const newWrongAttempts: number = getPuzzleBlocksFromAttempts(
-			newGame,
-			props.puzzle,
-		).reduce<number>((prev, current) => (arePuzzleBlocksSameGroup(current) ? prev : prev + 1), 0);
+		// Every attempt that didn't solve a group is a wrong attempt
+		const newWrongAttempts: number = newGame.attempts.length - newGame.correct.length;
 
-		setBlocks(props.puzzle.groups.flatMap((group) => group.blocks));
+		// NOTE: Blocks are already shuffled by the API
+		setBlocks(orderPuzzleBlocks(props.puzzle.blocks, props.puzzle.groups));
 		setGame(newGame);
 		setPuzzle(props.puzzle);
 		setSelected([]);
@@ -275,36 +217,61 @@ export function useGame(props: UseGameProps): UseGame {
 		toggleIsGameOver(
 			() =>
 				newWrongAttempts >= props.puzzle.max_attempts ||
-				(!!newGame.completed_at && newGame.correct.length !== props.puzzle.groups.length),
+				(!!newGame.completed_at && newGame.correct.length !== props.puzzle.group_count),
 		);
 		toggleIsLoading(false);
 		toggleIsWinnerWinnerChickenDinner(
-			() => !!newGame.completed_at && newGame.correct.length === props.puzzle.groups.length,
+			() => !!newGame.completed_at && newGame.correct.length === props.puzzle.group_count,
 		);
 		toggleIsWrong(false);
 
-		setBlocks((prev) => {
-			if (newGame.correct.length === 0) {
-				return shuffle(prev);
-			}
-			// 1. Filter blocks so only blocks that belong to a correct group is returned
-			// 2. Group by `group_id`
-			// 3. Flatten array
-			const grouped = groupBy(
-				prev.filter((b) => game.correct.includes(b.puzzle_group_id)),
-				(b) => b.puzzle_group_id,
-			).flat();
-
-			// 1. Order blocks by putting correctly guessed on top
-			// 2. Remove duplicates
-			const shuffled = shuffle(prev);
-
-			return uniqueBy([...grouped, ...shuffled], (item) => item.id);
-		});
-
 		// eslint-disable-next-line react-hooks/exhaustive-deps
 	}, [isLoading, local.isLoading]);
 
+	// When the API responds to the user's guess, update the game with the result
+	useEffect(() => {
+		if (fetcher.state !== "idle" || !fetcher.data) {
+			return;
+		}
+
+		// If the guess couldn't be checked, then, let the user try again
+		if (!fetcher.data.success) {
+			setSelected([]);
+			return;
+		}
+
+		const { data } = fetcher.data;
+		// Make sure the response is for the puzzle that's being played
+		if (data.puzzle.id !== puzzle.id) {
+			return;
+		}
+
+		const isCorrect = data.correct.length > game.correct.length;
+		const isGuessedAll = data.correct.length === data.puzzle.group_count;
+
+		setGame(transformGameToPayload(data));
+		setPuzzle((prev) => ({
+			...prev,
+			groups: data.puzzle.groups,
+		}));
+		setWrongAttempts(data.attempts.length - data.correct.length);
+
+		toggleIsGameOver(!!data.completed_at && !isGuessedAll);
+		toggleIsWinnerWinnerChickenDinner(!!data.completed_at && isGuessedAll);
+
+		if (!isCorrect) {
+			// Toggle `wrong` state to true to trigger animation
+			toggleIsWrong(true);
+			return;
+		}
+
+		// Update block states
+		setSelected([]);
+		setBlocks((prev) => orderPuzzleBlocks(prev, data.puzzle.groups));
+
+		// eslint-disable-next-line react-hooks/exhaustive-deps
+	}, [fetcher.data, fetcher.state]);
+
 	// When `isWrong` is true, reset it to false and clear `selected` after 300ms to play the animation
 	useEffect(() => {
 		if (!isWrong) {
@@ -349,6 +316,7 @@ export function useGame(props: UseGameProps): UseGame {
 			blocks,
 			game,
 			isGameOver,
+			isGuessing,
 			isLoading,
 			isWinnerWinnerChickenDinner,
 			isWrong,
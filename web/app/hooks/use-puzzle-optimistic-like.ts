@@ -9,7 +9,7 @@ import type { Response } from "@/types/response";
 
 export function usePuzzleOptimisticLike(
 	fetcher: Fetcher<Response<PuzzleLike>>,
-	puzzle: Puzzle,
+	puzzle: Pick<Puzzle, "liked_at" | "num_of_likes">,
 ): {
 	liked_at: Date | null | undefined;
 	num_of_likes: number;
@@ -44,9 +44,13 @@ export const GameLayoutGrid = forwardRef<ElementRef<typeof Primitive.div>, GameL
 
 							"[&>button:first-of-type]:data-[has-correct=false]:col-start-1 [&>button:first-of-type]:data-[has-correct=false]:col-end-1 [&>button:first-of-type]:data-[has-correct=false]:row-start-1 [&>button:first-of-type]:data-[has-correct=false]:row-end-1",
 						)}
+						columns={state.puzzle.group_size}
 						data-has-correct={false}
+						rows={state.puzzle.group_count}
 					>
-						{Array.from({ length: 16 }).map((_, i) => (
+						{Array.from({
+							length: state.puzzle.group_count * state.puzzle.group_size,
+						}).map((_, i) => (
 							<GridBlock disabled key={`GridBlock-skeleton-${i + 1}`} />
 						))}
 					</GridBlocks>
@@ -59,7 +63,9 @@ export const GameLayoutGrid = forwardRef<ElementRef<typeof Primitive.div>, GameL
 
 							"[&>button:first-of-type]:data-[has-correct=false]:col-start-1 [&>button:first-of-type]:data-[has-correct=false]:col-end-1 [&>button:first-of-type]:data-[has-correct=false]:row-start-1 [&>button:first-of-type]:data-[has-correct=false]:row-end-1",
 						)}
+						columns={state.puzzle.group_size}
 						data-has-correct={state.game.correct.length > 0}
+						rows={state.puzzle.group_count}
 					>
 						{state.game.correct.map((group_id) => {
 							const group = state.puzzle.groups.find((g) => g.id === group_id);
@@ -78,7 +84,9 @@ export const GameLayoutGrid = forwardRef<ElementRef<typeof Primitive.div>, GameL
 						})}
 
 						{state.blocks.map((block) => {
-							const isCorrect = state.game.correct.includes(block.puzzle_group_id);
+							const isCorrect = state.puzzle.groups.some((group) =>
+								group.blocks.includes(block.id),
+							);
 							const isSelected = state.selected.findIndex((b) => b.id === block.id) !== -1;
 
 							if (isCorrect) {
@@ -90,7 +98,7 @@ export const GameLayoutGrid = forwardRef<ElementRef<typeof Primitive.div>, GameL
 									data-error={isSelected && state.isWrong}
 									data-selected={isSelected && !state.isWrong}
 									disabled={state.isGameOver || state.isWinnerWinnerChickenDinner}
-									key={`${block.puzzle_group_id}-${block.value}`}
+									key={block.id}
 									onClick={() => actions.onBlockSelect(block)}
 								>
 									{block.value}
@@ -10,15 +10,16 @@ import { GameProvider, useGame } from "@/hooks/use-game";
 import { useGameLocalContext } from "@/hooks/use-game-local";
 import { useIsOnline } from "@/hooks/use-is-online";
 import { cn } from "@/lib/cn";
+import type { action as guessAction } from "@/routes/games.guess.$id";
 import type { action } from "@/routes/games.save.$id";
 import { type GamePayload } from "@/types/game-payload";
-import type { Puzzle } from "@/types/puzzle";
+import type { PlayerPuzzle } from "@/types/player-puzzle";
 import type { User } from "@/types/user";
 
 export type GameLayoutProps = ComponentPropsWithoutRef<typeof Primitive.div> & {
 	game?: GamePayload;
 	me?: User;
-	puzzle: Puzzle;
+	puzzle: PlayerPuzzle;
 };
 
 export const GameLayout = forwardRef<ElementRef<typeof Primitive.div>, GameLayoutProps>(
@@ -26,6 +27,9 @@ export const GameLayout = forwardRef<ElementRef<typeof Primitive.div>, GameLayou
 		const fetcher = useFetcher<typeof action>({
 			key: "games.save",
 		});
+		const guessFetcher = useFetcher<typeof guessAction>({
+			key: "games.guess",
+		});
 
 		const [localState, localActions] = useGameLocalContext();
 		const ctx = useGame({
@@ -88,6 +92,19 @@ export const GameLayout = forwardRef<ElementRef<typeof Primitive.div>, GameLayou
 				return;
 			}
 
+			// If the attempts were made through the API, then, they've already been saved
+			if (
+				guessFetcher.data?.success &&
+				guessFetcher.data.data.puzzle.id === state.puzzle.id &&
+				guessFetcher.data.data.attempts.length === state.game.attempts.length &&
+				// NOTE: Default to undefined if `completed_at` is null to ensure dayjs works properly
+				dayjs(guessFetcher.data.data.completed_at ?? undefined).isSame(
+					dayjs(state.game.completed_at ?? undefined),
+				)
+			) {
+				return;
+			}
+
 			switch (fetcher.state) {
 				case "loading":
 					notify.dismiss("games.save");
@@ -120,7 +137,7 @@ export const GameLayout = forwardRef<ElementRef<typeof Primitive.div>, GameLayou
 			}
 
 			// eslint-disable-next-line react-hooks/exhaustive-deps
-		}, [fetcher, isOnline, me, puzzle.id, state.game, state.puzzle.id]);
+		}, [fetcher, guessFetcher.data, isOnline, me, puzzle.id, state.game, state.puzzle.id]);
 
 		// Remove games from localStorage when it's been saved to the API
 		useEffect(() => {
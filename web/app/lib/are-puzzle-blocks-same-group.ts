@@ -1,10 +1,15 @@
-import type { PuzzleBlock } from "@/types/puzzle";
+import type { PlayerPuzzleBlock, PlayerPuzzleGroup } from "@/types/player-puzzle";
 
-export function arePuzzleBlocksSameGroup(attempt: PuzzleBlock[]): boolean {
-	const firstAttempt = attempt[0];
-	if (!firstAttempt) {
+/**
+ * Checks whether the given blocks make up one of the groups that the user has solved
+ */
+export function arePuzzleBlocksSameGroup(
+	attempt: PlayerPuzzleBlock[],
+	groups: PlayerPuzzleGroup[],
+): boolean {
+	if (attempt.length === 0) {
 		return false;
 	}
 
-	return attempt.every((block) => block.puzzle_group_id === firstAttempt.puzzle_group_id);
+	return groups.some((group) => attempt.every((block) => group.blocks.includes(block.id)));
 }
@@ -1,22 +1,19 @@
 import { z } from "zod";
 
-import type { Puzzle } from "@/types/puzzle";
+import type { PlayerPuzzle } from "@/types/player-puzzle";
 
-export function decodePuzzle(puzzle: Puzzle): Puzzle {
+export function decodePuzzle(puzzle: PlayerPuzzle): PlayerPuzzle {
 	return {
 		...puzzle,
-		groups: puzzle.groups.map((group) => ({
-			...group,
-			blocks: group.blocks.map((block) => {
-				if (!z.string().base64().safeParse(block.value).success) {
-					return block;
-				}
+		blocks: puzzle.blocks.map((block) => {
+			if (!z.string().base64().safeParse(block.value).success) {
+				return block;
+			}
 
-				return {
-					...block,
-					value: atob(block.value),
-				};
-			}),
-		})),
+			return {
+				...block,
+				value: atob(block.value),
+			};
+		}),
 	};
 }
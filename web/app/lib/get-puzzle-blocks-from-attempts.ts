@@ -1,16 +1,19 @@
 import type { GamePayload } from "@/types/game-payload";
-import type { Puzzle, PuzzleBlock } from "@/types/puzzle";
+import type { PlayerPuzzle, PlayerPuzzleBlock } from "@/types/player-puzzle";
 
 /**
- * Uses `game.attempts` id to retrieve `PuzzleBlock` from `puzzle`
+ * Uses `game.attempts` id to retrieve `PlayerPuzzleBlock` from `puzzle`
  */
-export function getPuzzleBlocksFromAttempts(game: GamePayload, puzzle: Puzzle): PuzzleBlock[][] {
-	const blocks = puzzle.groups.flatMap((group) => group.blocks);
+export function getPuzzleBlocksFromAttempts(
+	game: GamePayload,
+	puzzle: PlayerPuzzle,
+): PlayerPuzzleBlock[][] {
+	const { blocks } = puzzle;
 
-	const joined: PuzzleBlock[][] = [];
+	const joined: PlayerPuzzleBlock[][] = [];
 
 	for (let i = 0; i < game.attempts.length; i += 1) {
-		const temp: PuzzleBlock[] = [];
+		const temp: PlayerPuzzleBlock[] = [];
 
 		const attempt = game.attempts[i];
 		if (!attempt) {
@@ -1,7 +1,7 @@
-import type { Game } from "@/types/game";
 import type { History } from "@/types/history";
 import { HistorySchema } from "@/types/history";
-import type { PuzzleNode } from "@/types/puzzle-node";
+import type { PlayerGame } from "@/types/player-game";
+import type { PlayerPuzzleNode } from "@/types/player-puzzle-node";
 
 const MAX_SIZE = 10;
 
@@ -15,7 +15,7 @@ export function getHistory(obj: any): History {
 		: history.data;
 }
 
-export function completePuzzle(game: Game, history: History): History {
+export function completePuzzle(game: PlayerGame, history: History): History {
 	if (!game.completed_at) {
 		return history;
 	}
@@ -36,7 +36,7 @@ export function completePuzzle(game: Game, history: History): History {
 	};
 }
 
-export function updateHistory(current: PuzzleNode, history: History): History {
+export function updateHistory(current: PlayerPuzzleNode, history: History): History {
 	const updated = { ...history };
 
 	const isCurrent = current.cursor === history.puzzles?.[0]?.cursor;
@@ -0,0 +1,16 @@
+import { uniqueBy } from "@/lib/unique-by";
+import type { PlayerPuzzleBlock, PlayerPuzzleGroup } from "@/types/player-puzzle";
+
+/**
+ * Orders `blocks` by putting the blocks of the solved `groups` on top, in the order that they were solved
+ */
+export function orderPuzzleBlocks(
+	blocks: PlayerPuzzleBlock[],
+	groups: PlayerPuzzleGroup[],
+): PlayerPuzzleBlock[] {
+	const solved = groups.flatMap((group) =>
+		group.blocks.flatMap((id) => blocks.filter((block) => block.id === id)),
+	);
+
+	return uniqueBy([...solved, ...blocks], (block) => block.id);
+}
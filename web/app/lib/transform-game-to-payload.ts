@@ -1,7 +1,7 @@
-import type { Game } from "@/types/game";
 import type { GamePayload } from "@/types/game-payload";
+import type { PlayerGame } from "@/types/player-game";
 
-export function transformGameToPayload(game: Game): GamePayload {
+export function transformGameToPayload(game: PlayerGame): GamePayload {
 	return {
 		score: game.score,
 
@@ -60,6 +60,7 @@ export default [
 	// Action only routes
 	// ---
 
+	route("games/guess/:id", "routes/games.guess.$id.tsx"),
 	route("games/save/:id", "routes/games.save.$id.tsx"),
 	route("games/sync/:id", "routes/games.sync.$id.tsx"),
 ] satisfies RouteConfig;
@@ -93,12 +93,9 @@ export async function loader({ request }: Route.LoaderArgs) {
 				pageInfo: puzzles.data.page_info,
 				puzzle: {
 					...edge.node,
-					groups: edge.node.groups.map((group) => ({
-						...group,
-						blocks: group.blocks.map((block) => ({
-							...block,
-							value: btoa(block.value),
-						})),
+					blocks: edge.node.blocks.map((block) => ({
+						...block,
+						value: btoa(block.value),
 					})),
 				},
 			},
@@ -119,12 +116,9 @@ export async function loader({ request }: Route.LoaderArgs) {
 			pageInfo: puzzles.data.page_info,
 			puzzle: {
 				...edge.node,
-				groups: edge.node.groups.map((group) => ({
-					...group,
-					blocks: group.blocks.map((block) => ({
-						...block,
-						value: btoa(block.value),
-					})),
+				blocks: edge.node.blocks.map((block) => ({
+					...block,
+					value: btoa(block.value),
 				})),
 			},
 		},
@@ -141,7 +135,12 @@ export function shouldRevalidate({
 	formAction,
 }: ShouldRevalidateFunctionArgs) {
 	// If the user likes a puzzle or updates their profile, then, there's no need to revalidate current route's loader
-	const needsRevalidation = ["/games/save", "/games/sync", "/puzzles/like"].some((value) => {
+	const needsRevalidation = [
+		"/games/guess",
+		"/games/save",
+		"/games/sync",
+		"/puzzles/like",
+	].some((value) => {
 		if (!formAction) {
 			return false;
 		}
@@ -0,0 +1,69 @@
+import { data, redirect } from "react-router";
+
+import { completePuzzle, getHistory } from "@/lib/history";
+import { requireUser } from "@/lib/require-user";
+import { API } from "@/services/api.server";
+import { history as historyCookie } from "@/services/cookies.server";
+import { dataWithError } from "@/services/toast.server";
+import { GameGuessPayloadSchema } from "@/types/game-guess-payload";
+import type { PlayerGame } from "@/types/player-game";
+import type { Response } from "@/types/response";
+
+import type { Route } from "./+types/games.guess.$id";
+
+export async function action({ params, request }: Route.ActionArgs) {
+	// Make sure the request is a POST request
+	if (request.method.toUpperCase() !== "POST") {
+		// eslint-disable-next-line @typescript-eslint/no-throw-literal
+		throw redirect("/", {
+			status: 405,
+		});
+	}
+
+	await requireUser(request);
+
+	const response: Response<PlayerGame> = {
+		success: false,
+		error: {
+			code: "Internal",
+			message: "",
+		},
+	};
+
+	const json = await request.json();
+	const payload = GameGuessPayloadSchema.safeParse(json);
+	if (!payload.success) {
+		response.error.message = payload.error.issues
+			.map((issue) => `${issue.path} - ${issue.message}`)
+			.join(", ");
+
+		return dataWithError(response, {
+			description: response.error.message,
+			message: "Failed to guess!",
+		});
+	}
+
+	const game = await API.games.guess(request, { payload: payload.data, puzzleID: params.id ?? "" });
+	if (!game.success) {
+		return dataWithError(game, {
+			description: game.error.message,
+			message: "Failed to guess!",
+		});
+	}
+
+	// Remove from history if completed
+	if (game.data.completed_at) {
+		const cookie = request.headers.get("Cookie");
+		const history = getHistory((await historyCookie.parse(cookie)) ?? {});
+
+		const updated = completePuzzle(game.data, history);
+
+		return data(game, {
+			headers: {
+				"Set-Cookie": await historyCookie.serialize(updated),
+			},
+		});
+	}
+
+	return data(game);
+}
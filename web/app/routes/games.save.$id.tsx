@@ -5,8 +5,8 @@ import { requireUser } from "@/lib/require-user";
 import { API } from "@/services/api.server";
 import { history as historyCookie } from "@/services/cookies.server";
 import { dataWithError, dataWithSuccess } from "@/services/toast.server";
-import type { Game } from "@/types/game";
 import { GamePayloadSchema } from "@/types/game-payload";
+import type { PlayerGame } from "@/types/player-game";
 import type { Response } from "@/types/response";
 
 import type { Route } from "./+types/games.save.$id";
@@ -22,7 +22,7 @@ export async function action({ params, request }: Route.ActionArgs) {
 
 	await requireUser(request);
 
-	const response: Response<Game> = {
+	const response: Response<PlayerGame> = {
 		success: false,
 		error: {
 			code: "Internal",
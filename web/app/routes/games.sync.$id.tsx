@@ -2,8 +2,8 @@ import { data, redirect } from "react-router";
 
 import { requireUser } from "@/lib/require-user";
 import { API } from "@/services/api.server";
-import type { Game } from "@/types/game";
 import { GamePayloadSchema } from "@/types/game-payload";
+import type { PlayerGame } from "@/types/player-game";
 import type { Response } from "@/types/response";
 
 import type { Route } from "./+types/games.sync.$id";
@@ -19,7 +19,7 @@ export async function action({ params, request }: Route.ActionArgs) {
 
 	await requireUser(request);
 
-	const response: Response<Game> = {
+	const response: Response<PlayerGame> = {
 		success: false,
 		error: {
 			code: "Internal",
@@ -32,12 +32,9 @@ export async function loader({ params, request }: Route.LoaderArgs) {
 			me: undefined,
 			puzzle: {
 				...puzzle.data,
-				groups: puzzle.data.groups.map((group) => ({
-					...group,
-					blocks: group.blocks.map((block) => ({
-						...block,
-						value: btoa(block.value),
-					})),
+				blocks: puzzle.data.blocks.map((block) => ({
+					...block,
+					value: btoa(block.value),
 				})),
 			},
 		};
@@ -49,12 +46,9 @@ export async function loader({ params, request }: Route.LoaderArgs) {
 		me: me.data.user,
 		puzzle: {
 			...puzzle.data,
-			groups: puzzle.data.groups.map((group) => ({
-				...group,
-				blocks: group.blocks.map((block) => ({
-					...block,
-					value: btoa(block.value),
-				})),
+			blocks: puzzle.data.blocks.map((block) => ({
+				...block,
+				value: btoa(block.value),
 			})),
 		},
 	};
@@ -65,7 +59,12 @@ export function shouldRevalidate({
 	formAction,
 }: ShouldRevalidateFunctionArgs) {
 	// If the user likes a puzzle or updates their profile, then, there's no need to revalidate current route's loader
-	const needsRevalidation = ["/games/save", "/games/sync", "/puzzles/like"].some((value) => {
+	const needsRevalidation = [
+		"/games/guess",
+		"/games/save",
+		"/games/sync",
+		"/puzzles/like",
+	].some((value) => {
 		if (!formAction) {
 			return false;
 		}
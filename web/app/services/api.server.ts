@@ -1,8 +1,11 @@
 import { SUPPORTED_PROVIDERS } from "@/lib/constants";
 import { getSession } from "@/services/session.server";
-import type { Game } from "@/types/game";
+import type { GameGuessPayload } from "@/types/game-guess-payload";
 import type { GamePayload } from "@/types/game-payload";
 import type { GameSummaryConnection } from "@/types/game-summary-connection";
+import type { PlayerGame } from "@/types/player-game";
+import type { PlayerPuzzle } from "@/types/player-puzzle";
+import type { PlayerPuzzleConnection } from "@/types/player-puzzle-connection";
 import type { Puzzle } from "@/types/puzzle";
 import type { PuzzleConnection } from "@/types/puzzle-connection";
 import type { PuzzleCreatePayload } from "@/types/puzzle-create-payload";
@@ -113,7 +116,7 @@ export class API {
 		 * @param id - The ID of the puzzle
 		 * @returns The game, if any, that the user has played for the given puzzle
 		 */
-		async get(request: Request, id: string): Promise<Response<Game>> {
+		async get(request: Request, id: string): Promise<Response<PlayerGame>> {
 			const session = await getSession(request.headers.get("Cookie"));
 
 			const res = await fetch(`${API.URL}/${this.prefix}/${id}`, {
@@ -125,7 +128,37 @@ export class API {
 				method: "GET",
 			});
 
-			const response: Response<Game> = await res.json();
+			const response: Response<PlayerGame> = await res.json();
+			return response;
+		},
+
+		/**
+		 * Guesses that the given blocks belong to the same group
+		 *
+		 * Hits the `/games/:id/guess` endpoint on the API
+		 *
+		 * @param request - The incoming request
+		 * @param payload - The blocks that the user has selected
+		 * @param puzzleID - The puzzle that the game is for
+		 * @returns The game with the guess appended to its attempts
+		 */
+		async guess(
+			request: Request,
+			{ payload, puzzleID }: { payload: GameGuessPayload; puzzleID: string },
+		): Promise<Response<PlayerGame>> {
+			const session = await getSession(request.headers.get("Cookie"));
+
+			const res = await fetch(`${API.URL}/${this.prefix}/${puzzleID}/guess`, {
+				body: JSON.stringify(payload),
+				credentials: "include",
+				headers: {
+					"Content-Type": "application/json",
+					Authorization: `Bearer ${session.get("id") ?? ""}`,
+				},
+				method: "POST",
+			});
+
+			const response: Response<PlayerGame> = await res.json();
 			return response;
 		},
 
@@ -168,7 +201,7 @@ export class API {
 		async save(
 			request: Request,
 			{ payload, puzzleID }: { payload: GamePayload; puzzleID: string },
-		): Promise<Response<Game>> {
+		): Promise<Response<PlayerGame>> {
 			const session = await getSession(request.headers.get("Cookie"));
 
 			const res = await fetch(`${API.URL}/${this.prefix}/${puzzleID}`, {
@@ -181,7 +214,7 @@ export class API {
 				method: "PUT",
 			});
 
-			const response: Response<Game> = await res.json();
+			const response: Response<PlayerGame> = await res.json();
 			return response;
 		},
 	};
@@ -252,9 +285,9 @@ export class API {
 		 *
 		 * @param request - The incoming request
 		 * @param id - The ID of the puzzle
-		 * @returns Puzzle
+		 * @returns Puzzle, without the groups that the user hasn't solved yet
 		 */
-		async get(request: Request, id: string): Promise<Response<Puzzle>> {
+		async get(request: Request, id: string): Promise<Response<PlayerPuzzle>> {
 			const session = await getSession(request.headers.get("Cookie"));
 
 			const res = await fetch(`${API.URL}/${this.prefix}/${id}`, {
@@ -265,7 +298,7 @@ export class API {
 				method: "GET",
 			});
 
-			const response: Response<Puzzle> = await res.json();
+			const response: Response<PlayerPuzzle> = await res.json();
 			return response;
 		},
 
@@ -326,7 +359,7 @@ export class API {
 		 * @param request - The incoming request
 		 * @returns A list of the most recent puzzles that the user hasn't played yet
 		 */
-		async recent(request: Request): Promise<Response<PuzzleConnection>> {
+		async recent(request: Request): Promise<Response<PlayerPuzzleConnection>> {
 			const session = await getSession(request.headers.get("Cookie"));
 
 			const url = new URL(request.url);
@@ -344,7 +377,7 @@ export class API {
 				},
 			);
 
-			const response: Response<PuzzleConnection> = await res.json();
+			const response: Response<PlayerPuzzleConnection> = await res.json();
 			return response;
 		},
 
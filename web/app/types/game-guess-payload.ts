@@ -0,0 +1,8 @@
+import { z } from "zod";
+
+export const GameGuessPayloadSchema = z.object({
+	// Groups have between 3 and 6 blocks. The API checks that the guess has exactly `group_size` blocks
+	blocks: z.array(z.string()).min(3).max(6),
+});
+
+export type GameGuessPayload = z.infer<typeof GameGuessPayloadSchema>;
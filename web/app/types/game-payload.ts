@@ -2,10 +2,11 @@ import { z } from "zod";
 
 export const GamePayloadSchema = z
 	.object({
-		score: z.number().max(4).min(0),
+		// Puzzles have between 3 and 6 groups that each have between 3 and 6 blocks
+		score: z.number().max(6).min(0),
 
-		attempts: z.array(z.array(z.string()).length(4)).min(1),
-		correct: z.array(z.string()).max(4),
+		attempts: z.array(z.array(z.string()).min(3).max(6)).min(1),
+		correct: z.array(z.string()).max(6),
 
 		completed_at: z.nullable(z.optional(z.coerce.date())),
 	})
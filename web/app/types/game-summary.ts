@@ -1,8 +1,9 @@
 import type { Game } from "@/types/game";
 import type { PuzzleSummary } from "@/types/puzzle-summary";
 
-export type GameSummary = Omit<Game, "attempts" | "correct" | "puzzle"> & {
+export type GameSummary = Omit<Game, "attempts" | "correct" | "hints" | "puzzle"> & {
 	attempts: number;
+	hints: number;
 
 	puzzle: PuzzleSummary;
 };
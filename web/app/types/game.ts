@@ -1,6 +1,21 @@
 import type { Puzzle } from "@/types/puzzle";
 import type { User } from "@/types/user";
 
+export type GameHint = {
+	/**
+	 * Whether the group's hint or one of its blocks was revealed
+	 */
+	type: "HINT" | "BLOCK";
+	/**
+	 * Unique identifier of the group that the hint is for
+	 */
+	puzzle_group_id: string;
+	/**
+	 * Unique identifier of the block that was revealed. Only set when `type` is "BLOCK"
+	 */
+	puzzle_block_id?: string;
+};
+
 export type Game = {
 	/**
 	 * Unique identifier
@@ -18,6 +33,10 @@ export type Game = {
 	 * Group ids that the user was able to select correctly
 	 */
 	correct: string[];
+	/**
+	 * Hints that the user has revealed, in the order that they were revealed. Each hint costs a point of the score
+	 */
+	hints: GameHint[];
 	/**
 	 * When game was created
 	 */
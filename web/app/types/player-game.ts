@@ -0,0 +1,28 @@
+import type { Game, GameHint } from "@/types/game";
+import type { PlayerPuzzle } from "@/types/player-puzzle";
+
+export type PlayerGameHint = GameHint & {
+	/**
+	 * Creator's hint for the group. Only set when `type` is "HINT"
+	 */
+	hint?: string;
+};
+
+/**
+ * Player-facing representation of a game. The puzzle only includes the groups that the user has solved
+ */
+export type PlayerGame = Omit<Game, "hints" | "puzzle"> & {
+	/**
+	 * Hints that the user has revealed, in the order that they were revealed. Each hint costs a point of the score
+	 */
+	hints: PlayerGameHint[];
+	/**
+	 * Version of the puzzle that the game was started on
+	 */
+	puzzle_version: number;
+
+	/**
+	 * Puzzle that this game is for
+	 */
+	puzzle: PlayerPuzzle;
+};
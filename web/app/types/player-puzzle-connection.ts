@@ -0,0 +1,7 @@
+import type { PageInfo } from "@/types/page-info";
+import type { PlayerPuzzleNode } from "@/types/player-puzzle-node";
+
+export type PlayerPuzzleConnection = {
+	edges: PlayerPuzzleNode[];
+	page_info: PageInfo;
+};
@@ -0,0 +1,6 @@
+import type { PlayerPuzzle } from "@/types/player-puzzle";
+
+export type PlayerPuzzleNode = {
+	cursor: string;
+	node: PlayerPuzzle;
+};
@@ -0,0 +1,45 @@
+import type { Puzzle } from "@/types/puzzle";
+
+export type PlayerPuzzleBlock = {
+	/**
+	 * Unique identifier
+	 */
+	id: string;
+	/**
+	 * Text that will be displayed in the UI
+	 */
+	value: string;
+};
+
+export type PlayerPuzzleGroup = {
+	/**
+	 * Unique identifier
+	 */
+	id: string;
+	/**
+	 * Description for the group that describes the connection between blocks
+	 */
+	description: string;
+	/**
+	 * Unique identifiers of the blocks that belong to this group
+	 */
+	blocks: string[];
+};
+
+/**
+ * Player-facing representation of a puzzle. Blocks don't reveal the group that they belong to and only the groups that the user has solved are included
+ */
+export type PlayerPuzzle = Omit<Puzzle, "groups"> & {
+	/**
+	 * Version of the puzzle
+	 */
+	version: number;
+	/**
+	 * Every block of the puzzle, shuffled by the API. There are `group_count * group_size` blocks
+	 */
+	blocks: PlayerPuzzleBlock[];
+	/**
+	 * Groups that the user has solved, in the order that they were solved
+	 */
+	groups: PlayerPuzzleGroup[];
+};
@@ -12,6 +12,22 @@ export const PuzzleCreatePayloadSchema = z.object({
 			message: "Must be one of: Easy, Medium, or Hard.",
 		}),
 	}),
+	language: z
+		.enum(["de", "en", "es", "ja"], {
+			errorMap: () => ({
+				message: "Must be one of: German, English, Spanish, or Japanese.",
+			}),
+		})
+		.optional(),
+	tags: z
+		.array(
+			z
+				.string()
+				.max(32, "A tag must not have more than 32 characters!")
+				.min(1, "Required!"),
+		)
+		.max(5, "Must not have more than 5 tags!")
+		.optional(),
 
 	groups: z
 		.array(
@@ -20,6 +36,10 @@ export const PuzzleCreatePayloadSchema = z.object({
 					.string()
 					.max(512, "Must not have more than 512 characters!")
 					.min(1, "Required!"),
+				hint: z
+					.string()
+					.max(512, "Must not have more than 512 characters!")
+					.optional(),
 
 				blocks: z
 					.array(
@@ -1,3 +1,4 @@
+import type { Tag } from "@/types/tag";
 import type { User } from "@/types/user";
 
 export type PuzzleBlock = {
@@ -24,6 +25,10 @@ export type PuzzleGroup = {
 	 * Description for the group that describes the connection between blocks
 	 */
 	description: string;
+	/**
+	 * Optional clue that players can reveal at the cost of a point
+	 */
+	hint?: string;
 	/**
 	 * Blocks that belongs to this group
 	 */
@@ -43,6 +48,22 @@ export type Puzzle = {
 	 * Restricts the number of attempts a user can make. If this isn't explicitly set, then the `difficulty` will be used to determine the number of attempts.
 	 */
 	max_attempts: number;
+	/**
+	 * Number of groups in the puzzle
+	 */
+	group_count: number;
+	/**
+	 * Number of blocks in each group
+	 */
+	group_size: number;
+	/**
+	 * Language that the puzzle is written in, as an ISO 639-1 code
+	 */
+	language: "de" | "en" | "es" | "ja";
+	/**
+	 * Tags that describe the puzzle, ordered by their slug
+	 */
+	tags?: Tag[];
 	/**
 	 * Number of likes
 	 */
@@ -60,9 +81,7 @@ export type Puzzle = {
 	 */
 	updated_at?: Date;
 	/**
-	 * Group of blocks. For now, this is limited to 4.
-	 *
-	 * TODO: Allow this to be customizable
+	 * Group of blocks. There are `group_count` groups that each have `group_size` blocks
 	 */
 	groups: PuzzleGroup[];
 	/**
@@ -0,0 +1,22 @@
+export type Tag = {
+	/**
+	 * Unique identifier
+	 */
+	id: string;
+	/**
+	 * URL friendly form of the name that is used to look up the tag
+	 */
+	slug: string;
+	/**
+	 * Name of the tag, as it was first written
+	 */
+	name: string;
+	/**
+	 * Number of visible puzzles that have the tag
+	 */
+	num_of_puzzles: number;
+	/**
+	 * When the tag was created
+	 */
+	created_at: Date;
+};